@@ -43,10 +43,23 @@
 //
 // Change log level via HTTP:
 //
-//	curl http://localhost:8080/debug/loglevel/4
+//	curl -X POST http://localhost:8080/debug/loglevel/4
 //
 // The log level will automatically reset after 5 minutes.
 //
+// Protect admin handlers with an Authorizer:
+//
+//	authorizer := log.NewAuthorizerBearerToken(token)
+//	router.Handle("/debug/loglevel/{level}", log.NewAuthorizedHandler(
+//	    authorizer,
+//	    log.NewSetLoglevelHandler(ctx, logLevelSetter),
+//	))
+//
+// With the standard library http.ServeMux register all admin routes under a prefix:
+//
+//	mux := http.NewServeMux()
+//	log.RegisterAdminRoutes(mux, "/debug", authorizer, log.NewLogLevelAdminRoutes(ctx, logLevelSetter))
+//
 // # Sampler Types
 //
 // ModSampler - Sample every Nth occurrence:
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Router is the minimal interface needed to register admin routes.
+// *http.ServeMux implements it directly, use NewGorillaRouter for gorilla/mux.
+type Router interface {
+	Handle(pattern string, handler http.Handler)
+}
+
+// RouterFunc is a function type that implements the Router interface.
+// It allows any router to be used with RegisterAdminRoutes.
+type RouterFunc func(pattern string, handler http.Handler)
+
+// Handle implements the Router interface by calling the underlying function.
+func (r RouterFunc) Handle(pattern string, handler http.Handler) {
+	r(pattern, handler)
+}
+
+// NewGorillaRouter adapts a gorilla/mux router to the Router interface.
+func NewGorillaRouter(router *mux.Router) Router {
+	return RouterFunc(func(pattern string, handler http.Handler) {
+		router.Handle(pattern, handler)
+	})
+}
+
+// AdminRoute is a single admin handler together with its path pattern relative to the
+// prefix passed to RegisterAdminRoutes. Path wildcards use the "{name}" syntax understood
+// by both http.ServeMux and gorilla/mux.
+type AdminRoute struct {
+	Pattern string
+	Handler http.Handler
+}
+
+// AdminRoutes is a list of admin routes.
+type AdminRoutes []AdminRoute
+
+// NewLogLevelAdminRoutes returns the routes to change the log level:
+//
+//	POST {prefix}/loglevel/{level}  - level in path
+//	POST {prefix}/loglevel          - level as query parameter "level" or in the body
+func NewLogLevelAdminRoutes(ctx context.Context, logLevelSetter LogLevelSetter) AdminRoutes {
+	handler := NewLogLevelHandler(ctx, logLevelSetter, NewLogLevelParser())
+	return AdminRoutes{
+		{Pattern: "/loglevel/{level}", Handler: handler},
+		{Pattern: "/loglevel", Handler: handler},
+	}
+}
+
+// RegisterAdminRoutes registers all given admin routes on the router under the prefix.
+// Every handler is wrapped with NewAuthorizedHandler, so the authorizer applies to all of them.
+//
+// Example with the standard library:
+//
+//	mux := http.NewServeMux()
+//	log.RegisterAdminRoutes(
+//	    mux,
+//	    "/debug",
+//	    log.NewAuthorizerBearerToken(token),
+//	    log.NewLogLevelAdminRoutes(ctx, logLevelSetter),
+//	)
+//
+// Example with gorilla/mux:
+//
+//	router := mux.NewRouter()
+//	log.RegisterAdminRoutes(
+//	    log.NewGorillaRouter(router),
+//	    "/debug",
+//	    log.NewAuthorizerBearerToken(token),
+//	    log.NewLogLevelAdminRoutes(ctx, logLevelSetter),
+//	)
+func RegisterAdminRoutes(
+	router Router,
+	prefix string,
+	authorizer Authorizer,
+	routesList ...AdminRoutes,
+) {
+	prefix = strings.TrimSuffix(prefix, "/")
+	for _, routes := range routesList {
+		for _, route := range routes {
+			router.Handle(prefix+route.Pattern, NewAuthorizedHandler(authorizer, route.Handler))
+		}
+	}
+}
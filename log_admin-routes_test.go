@@ -0,0 +1,125 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log AdminRoutes", func() {
+	var ctx context.Context
+	var mockLogLevelSetter *mocks.LogLevelSetter
+	var authorizer log.Authorizer
+	var router http.Handler
+	var resp *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mockLogLevelSetter = &mocks.LogLevelSetter{}
+		authorizer = log.NewAuthorizerBearerToken("secret")
+		resp = httptest.NewRecorder()
+	})
+
+	serve := func(method string, target string, body string) {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		router.ServeHTTP(resp, req)
+	}
+
+	Context("with http.ServeMux", func() {
+		BeforeEach(func() {
+			serveMux := http.NewServeMux()
+			log.RegisterAdminRoutes(
+				serveMux,
+				"/debug/",
+				authorizer,
+				log.NewLogLevelAdminRoutes(ctx, mockLogLevelSetter),
+			)
+			router = serveMux
+		})
+		It("sets level from path", func() {
+			serve(http.MethodPost, "/debug/loglevel/4", "")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal("set loglevel to 4 completed\n"))
+			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(1))
+			_, level := mockLogLevelSetter.SetArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(4)))
+		})
+		It("sets level from query", func() {
+			serve(http.MethodPost, "/debug/loglevel?level=3", "")
+			Expect(resp.Body.String()).To(Equal("set loglevel to 3 completed\n"))
+			_, level := mockLogLevelSetter.SetArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(3)))
+		})
+		It("sets level from body", func() {
+			serve(http.MethodPost, "/debug/loglevel", `{"level":2}`)
+			Expect(resp.Body.String()).To(Equal("set loglevel to 2 completed\n"))
+			_, level := mockLogLevelSetter.SetArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(2)))
+		})
+		It("rejects unauthorized requests", func() {
+			req := httptest.NewRequest(http.MethodPost, "/debug/loglevel/4", nil)
+			router.ServeHTTP(resp, req)
+			Expect(resp.Code).To(Equal(http.StatusUnauthorized))
+			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
+		})
+		It("rejects GET requests", func() {
+			serve(http.MethodGet, "/debug/loglevel/4", "")
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("with gorilla/mux", func() {
+		BeforeEach(func() {
+			muxRouter := mux.NewRouter()
+			log.RegisterAdminRoutes(
+				log.NewGorillaRouter(muxRouter),
+				"/debug",
+				authorizer,
+				log.NewLogLevelAdminRoutes(ctx, mockLogLevelSetter),
+			)
+			router = muxRouter
+		})
+		It("sets level from path", func() {
+			serve(http.MethodPost, "/debug/loglevel/4", "")
+			Expect(resp.Body.String()).To(Equal("set loglevel to 4 completed\n"))
+			_, level := mockLogLevelSetter.SetArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(4)))
+		})
+		It("sets level from query", func() {
+			serve(http.MethodPut, "/debug/loglevel?level=1", "")
+			Expect(resp.Body.String()).To(Equal("set loglevel to 1 completed\n"))
+			_, level := mockLogLevelSetter.SetArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(1)))
+		})
+	})
+
+	Context("RouterFunc", func() {
+		It("calls the wrapped function", func() {
+			var patterns []string
+			log.RegisterAdminRoutes(
+				log.RouterFunc(func(pattern string, handler http.Handler) {
+					patterns = append(patterns, pattern)
+				}),
+				"/admin",
+				authorizer,
+				log.NewLogLevelAdminRoutes(ctx, mockLogLevelSetter),
+			)
+			Expect(patterns).To(Equal([]string{"/admin/loglevel/{level}", "/admin/loglevel"}))
+		})
+	})
+})
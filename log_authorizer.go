@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"crypto/subtle"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+// ErrUnauthorized is returned by an Authorizer if the request carries no or invalid credentials.
+// NewAuthorizedHandler answers such requests with 401 Unauthorized.
+var ErrUnauthorized = stderrors.New("unauthorized")
+
+// ErrForbidden is returned by an Authorizer if the request is not allowed to access the handler.
+// NewAuthorizedHandler answers such requests with 403 Forbidden.
+var ErrForbidden = stderrors.New("forbidden")
+
+//counterfeiter:generate -o mocks/log-authorizer.go --fake-name Authorizer . Authorizer
+
+// Authorizer decides whether a request is allowed to access an admin handler.
+// Use NewAuthorizedHandler to protect any handler provided by this package.
+type Authorizer interface {
+	// Authorize returns nil if the request is allowed, otherwise an error
+	// wrapping ErrUnauthorized or ErrForbidden.
+	Authorize(req *http.Request) error
+}
+
+// AuthorizerFunc is a function type that implements the Authorizer interface.
+// It allows custom authorization logic to be used as Authorizer.
+type AuthorizerFunc func(req *http.Request) error
+
+// Authorize implements the Authorizer interface by calling the underlying function.
+func (a AuthorizerFunc) Authorize(req *http.Request) error {
+	return a(req)
+}
+
+// AuthorizerList combines multiple authorizers using AND logic.
+// A request is only allowed if ALL contained authorizers allow it.
+//
+// Example:
+//
+//	// Require a bearer token AND a request from the cluster network
+//	authorizer := log.AuthorizerList{
+//	    log.NewAuthorizerBearerToken(token),
+//	    cidrAuthorizer,
+//	}
+type AuthorizerList []Authorizer
+
+// Authorize implements the Authorizer interface and returns the first error of the contained authorizers.
+func (a AuthorizerList) Authorize(req *http.Request) error {
+	for _, authorizer := range a {
+		if err := authorizer.Authorize(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewAuthorizerBearerToken creates an Authorizer that requires the header
+// "Authorization: Bearer <token>". The token is compared in constant time.
+//
+// Example:
+//
+//	authorizer := log.NewAuthorizerBearerToken(os.Getenv("ADMIN_TOKEN"))
+//	router.Handle("/debug/loglevel/{level}", log.NewAuthorizedHandler(authorizer, handler))
+func NewAuthorizerBearerToken(token string) Authorizer {
+	return AuthorizerFunc(func(req *http.Request) error {
+		value, found := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !found || token == "" || !equalConstantTime(value, token) {
+			return errors.Wrapf(req.Context(), ErrUnauthorized, "invalid bearer token")
+		}
+		return nil
+	})
+}
+
+// NewAuthorizerBasicAuth creates an Authorizer that requires HTTP basic auth
+// with the given username and password. Both are compared in constant time.
+func NewAuthorizerBasicAuth(username string, password string) Authorizer {
+	return AuthorizerFunc(func(req *http.Request) error {
+		user, pass, ok := req.BasicAuth()
+		if !ok || password == "" {
+			return errors.Wrapf(req.Context(), ErrUnauthorized, "basic auth missing")
+		}
+		// evaluate both comparisons to avoid leaking which one failed
+		userOK := equalConstantTime(user, username)
+		passOK := equalConstantTime(pass, password)
+		if !userOK || !passOK {
+			return errors.Wrapf(req.Context(), ErrUnauthorized, "invalid basic auth")
+		}
+		return nil
+	})
+}
+
+// NewAuthorizerCIDR creates an Authorizer that only allows requests whose remote address
+// is contained in one of the given CIDR ranges, e.g. "10.0.0.0/8" or "::1/128".
+//
+// The remote address is taken from http.Request.RemoteAddr. Headers like X-Forwarded-For
+// are ignored because they can be set by any client.
+//
+// Returns an error if one of the given CIDR ranges could not be parsed.
+func NewAuthorizerCIDR(ctx context.Context, cidrs ...string) (Authorizer, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "parse cidr '%s' failed", cidr)
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return AuthorizerFunc(func(req *http.Request) error {
+		addr, err := remoteAddr(req)
+		if err != nil {
+			return errors.Wrapf(req.Context(), ErrForbidden, "parse remote addr failed: %v", err)
+		}
+		for _, prefix := range prefixes {
+			if prefix.Contains(addr) {
+				return nil
+			}
+		}
+		return errors.Wrapf(req.Context(), ErrForbidden, "remote addr %s not allowed", addr)
+	}), nil
+}
+
+// NewAuthorizerAllowAll creates an Authorizer that allows every request.
+// It is intended for local development and tests only.
+func NewAuthorizerAllowAll() Authorizer {
+	return AuthorizerFunc(func(req *http.Request) error {
+		return nil
+	})
+}
+
+// NewAuthorizedHandler wraps the given handler and only calls it if the authorizer allows the request.
+// Requests rejected with ErrUnauthorized are answered with 401, all other rejections with 403.
+//
+// The wrapper can be applied to every admin handler provided by this package:
+//
+//	authorizer := log.NewAuthorizerBearerToken(token)
+//	router.Handle(
+//	    "/debug/loglevel/{level}",
+//	    log.NewAuthorizedHandler(authorizer, log.NewSetLoglevelHandler(ctx, logLevelSetter)),
+//	)
+func NewAuthorizedHandler(authorizer Authorizer, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if err := authorizer.Authorize(req); err != nil {
+			if errors.Is(err, ErrUnauthorized) {
+				http.Error(resp, ErrUnauthorized.Error(), http.StatusUnauthorized)
+				return
+			}
+			http.Error(resp, ErrForbidden.Error(), http.StatusForbidden)
+			return
+		}
+		handler.ServeHTTP(resp, req)
+	})
+}
+
+// newStateChangingHandler wraps the given handler and rejects GET and HEAD requests with
+// 405 Method Not Allowed. All handlers that change state are wrapped with it.
+func newStateChangingHandler(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodGet || req.Method == http.MethodHead {
+			http.Error(
+				resp,
+				"method "+req.Method+" not allowed",
+				http.StatusMethodNotAllowed,
+			) // #nosec G705 - method is one of GET or HEAD
+			return
+		}
+		handler.ServeHTTP(resp, req)
+	})
+}
+
+func equalConstantTime(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func remoteAddr(req *http.Request) (netip.Addr, error) {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
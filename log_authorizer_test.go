@@ -0,0 +1,188 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log Authorizer", func() {
+	var ctx context.Context
+	var req *http.Request
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		req = httptest.NewRequest(http.MethodPost, "/debug/loglevel/3", nil)
+	})
+
+	Context("NewAuthorizerBearerToken", func() {
+		var authorizer log.Authorizer
+		BeforeEach(func() {
+			authorizer = log.NewAuthorizerBearerToken("secret")
+		})
+		It("allows matching token", func() {
+			req.Header.Set("Authorization", "Bearer secret")
+			Expect(authorizer.Authorize(req)).To(Succeed())
+		})
+		It("rejects wrong token", func() {
+			req.Header.Set("Authorization", "Bearer wrong")
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+		It("rejects missing header", func() {
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+		It("rejects other scheme", func() {
+			req.SetBasicAuth("user", "secret")
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+		It("rejects everything if token is empty", func() {
+			authorizer = log.NewAuthorizerBearerToken("")
+			req.Header.Set("Authorization", "Bearer ")
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+	})
+
+	Context("NewAuthorizerBasicAuth", func() {
+		var authorizer log.Authorizer
+		BeforeEach(func() {
+			authorizer = log.NewAuthorizerBasicAuth("admin", "secret")
+		})
+		It("allows matching credentials", func() {
+			req.SetBasicAuth("admin", "secret")
+			Expect(authorizer.Authorize(req)).To(Succeed())
+		})
+		It("rejects wrong password", func() {
+			req.SetBasicAuth("admin", "wrong")
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+		It("rejects wrong username", func() {
+			req.SetBasicAuth("root", "secret")
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+		It("rejects missing credentials", func() {
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+	})
+
+	Context("NewAuthorizerCIDR", func() {
+		var authorizer log.Authorizer
+		BeforeEach(func() {
+			var err error
+			authorizer, err = log.NewAuthorizerCIDR(ctx, "10.0.0.0/8", "::1/128")
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("allows ipv4 address in range", func() {
+			req.RemoteAddr = "10.1.2.3:1234"
+			Expect(authorizer.Authorize(req)).To(Succeed())
+		})
+		It("allows ipv6 address in range", func() {
+			req.RemoteAddr = "[::1]:1234"
+			Expect(authorizer.Authorize(req)).To(Succeed())
+		})
+		It("allows ipv4-mapped ipv6 address in range", func() {
+			req.RemoteAddr = "[::ffff:10.1.2.3]:1234"
+			Expect(authorizer.Authorize(req)).To(Succeed())
+		})
+		It("rejects address out of range", func() {
+			req.RemoteAddr = "192.168.1.1:1234"
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrForbidden)).To(BeTrue())
+		})
+		It("ignores X-Forwarded-For", func() {
+			req.RemoteAddr = "192.168.1.1:1234"
+			req.Header.Set("X-Forwarded-For", "10.1.2.3")
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrForbidden)).To(BeTrue())
+		})
+		It("rejects invalid remote address", func() {
+			req.RemoteAddr = "invalid"
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrForbidden)).To(BeTrue())
+		})
+		It("returns error for invalid cidr", func() {
+			_, err := log.NewAuthorizerCIDR(ctx, "10.0.0.0/33")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("AuthorizerFunc", func() {
+		It("calls the wrapped function", func() {
+			var received *http.Request
+			authorizer := log.AuthorizerFunc(func(req *http.Request) error {
+				received = req
+				return nil
+			})
+			Expect(authorizer.Authorize(req)).To(Succeed())
+			Expect(received).To(Equal(req))
+		})
+	})
+
+	Context("AuthorizerList", func() {
+		It("allows if all authorizers allow", func() {
+			authorizer := log.AuthorizerList{
+				log.NewAuthorizerAllowAll(),
+				log.NewAuthorizerAllowAll(),
+			}
+			Expect(authorizer.Authorize(req)).To(Succeed())
+		})
+		It("rejects if one authorizer rejects", func() {
+			authorizer := log.AuthorizerList{
+				log.NewAuthorizerAllowAll(),
+				log.NewAuthorizerBearerToken("secret"),
+			}
+			Expect(errors.Is(authorizer.Authorize(req), log.ErrUnauthorized)).To(BeTrue())
+		})
+		It("allows if empty", func() {
+			Expect(log.AuthorizerList{}.Authorize(req)).To(Succeed())
+		})
+	})
+
+	Context("NewAuthorizedHandler", func() {
+		var authorizer *mocks.Authorizer
+		var handler http.Handler
+		var handlerCallCount int
+		var resp *httptest.ResponseRecorder
+
+		BeforeEach(func() {
+			authorizer = &mocks.Authorizer{}
+			handlerCallCount = 0
+			handler = http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+				handlerCallCount++
+			})
+			resp = httptest.NewRecorder()
+		})
+		It("calls handler if authorized", func() {
+			authorizer.AuthorizeReturns(nil)
+			log.NewAuthorizedHandler(authorizer, handler).ServeHTTP(resp, req)
+			Expect(handlerCallCount).To(Equal(1))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+		It("returns 401 if unauthorized", func() {
+			authorizer.AuthorizeReturns(log.ErrUnauthorized)
+			log.NewAuthorizedHandler(authorizer, handler).ServeHTTP(resp, req)
+			Expect(handlerCallCount).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusUnauthorized))
+		})
+		It("returns 403 if forbidden", func() {
+			authorizer.AuthorizeReturns(log.ErrForbidden)
+			log.NewAuthorizedHandler(authorizer, handler).ServeHTTP(resp, req)
+			Expect(handlerCallCount).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+		})
+		It("returns 403 for custom errors", func() {
+			authorizer.AuthorizeReturns(errors.New("banana"))
+			log.NewAuthorizedHandler(authorizer, handler).ServeHTTP(resp, req)
+			Expect(handlerCallCount).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(resp.Body.String()).ToNot(ContainSubstring("banana"))
+		})
+	})
+})
@@ -0,0 +1,326 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/bborbe/errors"
+)
+
+const (
+	// DefaultCgroupPath is the mount point of the cgroup filesystem.
+	DefaultCgroupPath = "/sys/fs/cgroup"
+	// DefaultProcCgroupPath is the cgroup membership file of the current process.
+	DefaultProcCgroupPath = "/proc/self/cgroup"
+	// DefaultProcStatusPath is the status file of the current process.
+	DefaultProcStatusPath = "/proc/self/status"
+)
+
+// cgroupV1UnlimitedLimit is the lower bound of the values cgroup v1 reports for no limit,
+// the exact value depends on the page size.
+const cgroupV1UnlimitedLimit = 1 << 62
+
+//counterfeiter:generate -o mocks/log-container-memory-reader.go --fake-name ContainerMemoryReader . ContainerMemoryReader
+
+// ContainerMemoryReader reads the memory usage and limit of the container.
+type ContainerMemoryReader interface {
+	// ReadContainerMemory returns the current container memory.
+	ReadContainerMemory(ctx context.Context) (ContainerMemory, error)
+}
+
+// ContainerMemoryReaderFunc is a function type that implements the ContainerMemoryReader interface.
+type ContainerMemoryReaderFunc func(ctx context.Context) (ContainerMemory, error)
+
+// ReadContainerMemory implements the ContainerMemoryReader interface by calling the function.
+func (c ContainerMemoryReaderFunc) ReadContainerMemory(
+	ctx context.Context,
+) (ContainerMemory, error) {
+	return c(ctx)
+}
+
+// ContainerMemory holds the memory usage of the cgroup and the process as seen by the kernel.
+type ContainerMemory struct {
+	// CgroupVersion is 1 or 2, zero if no cgroup memory controller was found.
+	CgroupVersion int `json:"cgroupVersion,omitempty"`
+	// Limit is the cgroup memory limit in bytes, zero if unlimited.
+	Limit uint64 `json:"limit,omitempty"`
+	// Usage is the current memory usage of the cgroup in bytes, including page cache.
+	Usage uint64 `json:"usage,omitempty"`
+	// RSS is the resident set size of the process in bytes, zero if unknown.
+	RSS uint64 `json:"rss,omitempty"`
+}
+
+// UsagePercent returns Usage in percent of Limit, zero if unlimited.
+func (c ContainerMemory) UsagePercent() float64 {
+	return percentOf(c.Usage, c.Limit)
+}
+
+// RSSPercent returns RSS in percent of Limit, zero if unlimited.
+func (c ContainerMemory) RSSPercent() float64 {
+	return percentOf(c.RSS, c.Limit)
+}
+
+// MarshalJSON adds UsagePercent and RSSPercent as usagePercent and rssPercent.
+func (c ContainerMemory) MarshalJSON() ([]byte, error) {
+	type containerMemory ContainerMemory
+	return json.Marshal(struct {
+		containerMemory
+		UsagePercent float64 `json:"usagePercent,omitempty"`
+		RSSPercent   float64 `json:"rssPercent,omitempty"`
+	}{
+		containerMemory: containerMemory(c),
+		UsagePercent:    c.UsagePercent(),
+		RSSPercent:      c.RSSPercent(),
+	})
+}
+
+// String formats the container memory for log lines.
+func (c ContainerMemory) String() string {
+	var parts []string
+	switch {
+	case c.CgroupVersion == 0:
+	case c.Limit == 0:
+		parts = append(parts, fmt.Sprintf(
+			"Cgroup v%d: %.2f MB (no limit)",
+			c.CgroupVersion,
+			toMB(c.Usage),
+		))
+	default:
+		parts = append(parts, fmt.Sprintf(
+			"Cgroup v%d: %.2f MB of %.2f MB (%.1f%%)",
+			c.CgroupVersion,
+			toMB(c.Usage),
+			toMB(c.Limit),
+			c.UsagePercent(),
+		))
+	}
+	switch {
+	case c.RSS == 0:
+	case c.Limit == 0:
+		parts = append(parts, fmt.Sprintf("RSS: %.2f MB", toMB(c.RSS)))
+	default:
+		parts = append(parts, fmt.Sprintf("RSS: %.2f MB (%.1f%%)", toMB(c.RSS), c.RSSPercent()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// NewContainerMemoryReader returns a ContainerMemoryReader for the cgroup of the process,
+// usually with DefaultCgroupPath, DefaultProcCgroupPath and DefaultProcStatusPath.
+// The cgroup of the process is read from procCgroupPath ("0::/path" for cgroup v2, the line
+// with the memory controller for cgroup v1) and resolved below the mount point cgroupPath.
+// If the resolved directory does not exist, e.g. inside a cgroup namespace, the mount point
+// itself is used. cgroup v2 is read from memory.current and memory.max, where the lowest
+// memory.max of the cgroup and its ancestors is the limit. cgroup v1 is read from
+// memory/memory.usage_in_bytes and memory/memory.limit_in_bytes. The RSS is read from
+// procStatusPath.
+// Missing files are not an error, e.g. outside a container or on other operating systems
+// the result is simply empty.
+func NewContainerMemoryReader(
+	cgroupPath string,
+	procCgroupPath string,
+	procStatusPath string,
+) ContainerMemoryReader {
+	return ContainerMemoryReaderFunc(func(ctx context.Context) (ContainerMemory, error) {
+		cgroups, err := readProcCgroup(ctx, procCgroupPath)
+		if err != nil {
+			return ContainerMemory{}, err
+		}
+		containerMemory, err := readCgroupMemory(ctx, cgroupPath, cgroups)
+		if err != nil {
+			return ContainerMemory{}, err
+		}
+		containerMemory.RSS, err = readProcStatusRSS(ctx, procStatusPath)
+		if err != nil {
+			return ContainerMemory{}, err
+		}
+		return containerMemory, nil
+	})
+}
+
+// procCgroups are the cgroup paths of the process relative to the mount point,
+// empty if unknown.
+type procCgroups struct {
+	v2       string
+	v1Memory string
+}
+
+// readProcCgroup parses a /proc/<pid>/cgroup file with lines like
+// "0::/kubepods/pod1/ctr" (cgroup v2) or "4:memory:/docker/abc" (cgroup v1).
+func readProcCgroup(ctx context.Context, path string) (procCgroups, error) {
+	var result procCgroups
+	content, err := os.ReadFile(path) // #nosec G304 -- path is the configured cgroup file
+	if err != nil {
+		if os.IsNotExist(err) {
+			return result, nil
+		}
+		return result, errors.Wrapf(ctx, err, "read %s failed", path)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			result.v2 = fields[2]
+			continue
+		}
+		for _, controller := range strings.Split(fields[1], ",") {
+			if controller == "memory" {
+				result.v1Memory = fields[2]
+			}
+		}
+	}
+	return result, nil
+}
+
+// resolveCgroupDir returns the directory of the cgroup below root, root if it does not exist.
+func resolveCgroupDir(root string, cgroup string) string {
+	root = filepath.Clean(root)
+	if cgroup == "" {
+		return root
+	}
+	dir := filepath.Join(root, cgroup)
+	if _, err := os.Stat(dir); err != nil {
+		return root
+	}
+	return dir
+}
+
+func readCgroupMemory(
+	ctx context.Context,
+	cgroupPath string,
+	cgroups procCgroups,
+) (ContainerMemory, error) {
+	dir := resolveCgroupDir(cgroupPath, cgroups.v2)
+	usage, found, err := readCgroupValue(ctx, filepath.Join(dir, "memory.current"))
+	if err != nil {
+		return ContainerMemory{}, err
+	}
+	if found {
+		limit, err := readCgroupV2Limit(ctx, filepath.Clean(cgroupPath), dir)
+		if err != nil {
+			return ContainerMemory{}, err
+		}
+		return ContainerMemory{CgroupVersion: 2, Limit: limit, Usage: usage}, nil
+	}
+
+	dir = resolveCgroupDir(filepath.Join(cgroupPath, "memory"), cgroups.v1Memory)
+	usage, found, err = readCgroupValue(ctx, filepath.Join(dir, "memory.usage_in_bytes"))
+	if err != nil || !found {
+		return ContainerMemory{}, err
+	}
+	limit, _, err := readCgroupValue(ctx, filepath.Join(dir, "memory.limit_in_bytes"))
+	if err != nil {
+		return ContainerMemory{}, err
+	}
+	if limit >= cgroupV1UnlimitedLimit {
+		limit = 0
+	}
+	return ContainerMemory{CgroupVersion: 1, Limit: limit, Usage: usage}, nil
+}
+
+// readCgroupV2Limit returns the lowest memory.max of dir and its ancestors up to root,
+// zero if none is limited. A missing memory.max, e.g. in the root cgroup, means no limit.
+func readCgroupV2Limit(ctx context.Context, root string, dir string) (uint64, error) {
+	var result uint64
+	for {
+		limit, _, err := readCgroupValue(ctx, filepath.Join(dir, "memory.max"))
+		if err != nil {
+			return 0, err
+		}
+		if limit > 0 && (result == 0 || limit < result) {
+			result = limit
+		}
+		if dir == root || !strings.HasPrefix(dir, root) {
+			return result, nil
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// readCgroupValue reads a single number from a cgroup file, "max" is returned as zero.
+func readCgroupValue(ctx context.Context, path string) (uint64, bool, error) {
+	// #nosec G304 -- path is built from the configured cgroup path
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Wrapf(ctx, err, "read %s failed", path)
+	}
+	value := string(bytes.TrimSpace(content))
+	if value == "max" {
+		return 0, true, nil
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, false, errors.Wrapf(ctx, err, "parse %s failed", path)
+	}
+	return result, true, nil
+}
+
+// readProcStatusRSS returns VmRSS of a /proc/<pid>/status file in bytes.
+func readProcStatusRSS(ctx context.Context, path string) (uint64, error) {
+	content, err := os.ReadFile(path) // #nosec G304 -- path is the configured status file
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, errors.Wrapf(ctx, err, "read %s failed", path)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		// e.g. "VmRSS:	   12345 kB"
+		value, ok := strings.CutPrefix(scanner.Text(), "VmRSS:")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(value)
+		if len(fields) != 2 || fields[1] != "kB" {
+			return 0, errors.Errorf(ctx, "unexpected VmRSS %q in %s", value, path)
+		}
+		kiloBytes, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(ctx, err, "parse VmRSS in %s failed", path)
+		}
+		return kiloBytes * 1024, nil
+	}
+	return 0, nil
+}
+
+// memoryLimits formats the container memory and the utilization of GOMEMLIMIT,
+// empty if neither is known.
+func memoryLimits(snapshot MemorySnapshot, containerMemory ContainerMemory) string {
+	var parts []string
+	if value := containerMemory.String(); value != "" {
+		parts = append(parts, value)
+	}
+	if snapshot.MemoryLimit > 0 && snapshot.MemoryLimit < math.MaxInt64 {
+		parts = append(parts, fmt.Sprintf(
+			"GOMEMLIMIT: %.2f MB (%.1f%%)",
+			toMB(snapshot.MemoryLimit),
+			percentOf(snapshot.Retained(), snapshot.MemoryLimit),
+		))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func percentOf(value uint64, limit uint64) float64 {
+	if limit == 0 {
+		return 0
+	}
+	return float64(value) / float64(limit) * 100
+}
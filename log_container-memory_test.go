@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log ContainerMemoryReader", func() {
+	var ctx context.Context
+	var containerMemory log.ContainerMemory
+	var err error
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	read := func(cgroupPath string, procCgroupPath string, procStatusPath string) {
+		containerMemory, err = log.NewContainerMemoryReader(
+			cgroupPath,
+			procCgroupPath,
+			procStatusPath,
+		).ReadContainerMemory(ctx)
+	}
+
+	It("reads cgroup v2", func() {
+		read("testdata/cgroup-v2", "testdata/proc/cgroup-v2-root", "testdata/proc/status")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory).To(Equal(log.ContainerMemory{
+			CgroupVersion: 2,
+			Limit:         512 * mb,
+			Usage:         384 * mb,
+			RSS:           200 * mb,
+		}))
+		Expect(containerMemory.UsagePercent()).To(Equal(75.0))
+		Expect(containerMemory.String()).To(Equal(
+			"Cgroup v2: 384.00 MB of 512.00 MB (75.0%), RSS: 200.00 MB (39.1%)",
+		))
+	})
+
+	It("reads cgroup v2 without limit", func() {
+		read("testdata/cgroup-v2-unlimited", "testdata/proc/cgroup-v2-root", "testdata/proc/status")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory.CgroupVersion).To(Equal(2))
+		Expect(containerMemory.Limit).To(BeZero())
+		Expect(containerMemory.Usage).To(Equal(uint64(384 * mb)))
+		Expect(containerMemory.UsagePercent()).To(BeZero())
+		Expect(containerMemory.String()).To(Equal(
+			"Cgroup v2: 384.00 MB (no limit), RSS: 200.00 MB",
+		))
+	})
+
+	It("reads cgroup v1", func() {
+		read("testdata/cgroup-v1", "testdata/proc/cgroup-v2-root", "testdata/proc/status")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory).To(Equal(log.ContainerMemory{
+			CgroupVersion: 1,
+			Limit:         1024 * mb,
+			Usage:         256 * mb,
+			RSS:           200 * mb,
+		}))
+		Expect(containerMemory.UsagePercent()).To(Equal(25.0))
+	})
+
+	It("reads cgroup v1 without limit", func() {
+		read("testdata/cgroup-v1-unlimited", "testdata/proc/cgroup-v2-root", "testdata/proc/status")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory.CgroupVersion).To(Equal(1))
+		Expect(containerMemory.Limit).To(BeZero())
+		Expect(containerMemory.Usage).To(Equal(uint64(256 * mb)))
+	})
+
+	It("reads the nested cgroup v2 of the process", func() {
+		read("testdata/cgroup-v2-nested", "testdata/proc/cgroup-v2", "testdata/proc/status")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory.CgroupVersion).To(Equal(2))
+		Expect(containerMemory.Usage).To(Equal(uint64(256 * mb)))
+		// the lowest limit of the ancestors
+		Expect(containerMemory.Limit).To(Equal(uint64(512 * mb)))
+	})
+
+	It("reads the cgroup v1 memory controller of the process", func() {
+		read("testdata/cgroup-v1-nested", "testdata/proc/cgroup-v1", "testdata/proc/status")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory).To(Equal(log.ContainerMemory{
+			CgroupVersion: 1,
+			Limit:         256 * mb,
+			Usage:         128 * mb,
+			RSS:           200 * mb,
+		}))
+	})
+
+	It("falls back to the mount point if the cgroup does not exist", func() {
+		read("testdata/cgroup-v2", "testdata/proc/cgroup-v2", "testdata/proc/status")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory.Usage).To(Equal(uint64(384 * mb)))
+		Expect(containerMemory.Limit).To(Equal(uint64(512 * mb)))
+	})
+
+	It("returns nothing for missing files", func() {
+		read("testdata/missing", "testdata/proc/missing", "testdata/proc/missing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(containerMemory).To(Equal(log.ContainerMemory{}))
+		Expect(containerMemory.String()).To(BeEmpty())
+	})
+
+	It("returns error for invalid values", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "memory.current"), []byte("banana\n"), 0o600)).
+			To(Succeed())
+		read(dir, "testdata/proc/cgroup-v2-root", "testdata/proc/status")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("returns error for an invalid status file", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "status")
+		Expect(os.WriteFile(path, []byte("VmRSS:\tbanana kB\n"), 0o600)).To(Succeed())
+		read("testdata/cgroup-v2", "testdata/proc/cgroup-v2-root", path)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("Log MemoryMonitor container memory", func() {
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+	var containerMemoryReader *mocks.ContainerMemoryReader
+	var memoryProfileWriter *mocks.MemoryProfileWriter
+	var memoryThresholds log.MemoryThresholds
+	var memoryMonitor log.MemoryMonitor
+
+	BeforeEach(func() {
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{
+			Time:        time.Now(),
+			Sys:         400 * mb,
+			MemoryLimit: math.MaxInt64,
+		})
+		containerMemoryReader = &mocks.ContainerMemoryReader{}
+		containerMemoryReader.ReadContainerMemoryReturns(log.ContainerMemory{
+			CgroupVersion: 2,
+			Limit:         500 * mb,
+			Usage:         450 * mb,
+		}, nil)
+		memoryProfileWriter = &mocks.MemoryProfileWriter{}
+		memoryThresholds = log.MemoryThresholds{LimitPercent: 70}
+	})
+
+	JustBeforeEach(func() {
+		memoryMonitor = log.NewMemoryMonitor(
+			0,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(containerMemoryReader),
+			log.WithMemoryThresholds(memoryThresholds),
+			log.WithMemoryProfileWriter(memoryProfileWriter),
+		)
+	})
+
+	It("checks the limit percent against the container limit", func() {
+		memoryMonitor.LogMemoryUsage("container")
+		Expect(containerMemoryReader.ReadContainerMemoryCallCount()).To(Equal(1))
+		Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(1))
+	})
+
+	Context("with explicit limit", func() {
+		BeforeEach(func() {
+			memoryThresholds.Limit = 1000 * mb
+		})
+
+		It("prefers the explicit limit", func() {
+			memoryMonitor.LogMemoryUsage("explicit")
+			Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(0))
+		})
+	})
+
+	It("checks the RSS against the limit if known", func() {
+		containerMemoryReader.ReadContainerMemoryReturns(log.ContainerMemory{
+			CgroupVersion: 2,
+			Limit:         500 * mb,
+			Usage:         450 * mb,
+			RSS:           300 * mb,
+		}, nil)
+		memoryMonitor.LogMemoryUsage("rss")
+		Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(0))
+	})
+
+	It("writes profiles without blocking other checkpoints", func() {
+		memoryProfileWriter.WriteProfilesStub = func(ctx context.Context) ([]string, error) {
+			// would deadlock if the monitor held its mutex
+			Expect(memoryMonitor.Checkpoints()).NotTo(BeEmpty())
+			return nil, nil
+		}
+		memoryMonitor.LogMemoryUsage("unlocked")
+		Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(1))
+	})
+
+	It("continues if reading the container memory fails", func() {
+		containerMemoryReader.ReadContainerMemoryReturns(
+			log.ContainerMemory{},
+			errors.New("banana"),
+		)
+		memoryMonitor.LogMemoryUsage("failed")
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(1))
+		Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(0))
+	})
+})
@@ -0,0 +1,131 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// cronSchedule is a parsed cron expression with the five fields
+// "minute hour day-of-month month day-of-week", evaluated in UTC.
+type cronSchedule struct {
+	minutes     [60]bool
+	hours       [24]bool
+	daysOfMonth [32]bool
+	months      [13]bool
+	daysOfWeek  [7]bool
+}
+
+// parseCronSchedule parses a cron expression like "0 2 * * *" (daily at 02:00 UTC) or
+// "*/15 8-18 * * 1-5". Each field supports "*", single values, ranges "a-b",
+// steps "*/n" or "a-b/n" and lists "a,b,c". Day-of-week 0 and 7 are Sunday.
+// All fields must match, unlike classic cron day-of-month and day-of-week are not OR-ed.
+func parseCronSchedule(ctx context.Context, expression string) (*cronSchedule, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, errors.Errorf(ctx, "cron '%s' must have 5 fields", expression)
+	}
+	var schedule cronSchedule
+	if err := parseCronField(ctx, fields[0], 0, 59, schedule.minutes[:]); err != nil {
+		return nil, errors.Wrapf(ctx, err, "parse minute of cron '%s' failed", expression)
+	}
+	if err := parseCronField(ctx, fields[1], 0, 23, schedule.hours[:]); err != nil {
+		return nil, errors.Wrapf(ctx, err, "parse hour of cron '%s' failed", expression)
+	}
+	if err := parseCronField(ctx, fields[2], 1, 31, schedule.daysOfMonth[:]); err != nil {
+		return nil, errors.Wrapf(ctx, err, "parse day-of-month of cron '%s' failed", expression)
+	}
+	if err := parseCronField(ctx, fields[3], 1, 12, schedule.months[:]); err != nil {
+		return nil, errors.Wrapf(ctx, err, "parse month of cron '%s' failed", expression)
+	}
+	var daysOfWeek [8]bool
+	if err := parseCronField(ctx, fields[4], 0, 7, daysOfWeek[:]); err != nil {
+		return nil, errors.Wrapf(ctx, err, "parse day-of-week of cron '%s' failed", expression)
+	}
+	copy(schedule.daysOfWeek[:], daysOfWeek[:7])
+	schedule.daysOfWeek[0] = schedule.daysOfWeek[0] || daysOfWeek[7]
+	return &schedule, nil
+}
+
+func parseCronField(
+	ctx context.Context,
+	field string,
+	minValue int,
+	maxValue int,
+	values []bool,
+) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return errors.Errorf(ctx, "invalid step '%s'", stepPart)
+			}
+		}
+		from, to, err := parseCronRange(ctx, rangePart, minValue, maxValue)
+		if err != nil {
+			return err
+		}
+		for value := from; value <= to; value += step {
+			values[value] = true
+		}
+	}
+	return nil
+}
+
+func parseCronRange(
+	ctx context.Context,
+	part string,
+	minValue int,
+	maxValue int,
+) (int, int, error) {
+	if part == "*" {
+		return minValue, maxValue, nil
+	}
+	fromPart, toPart, isRange := strings.Cut(part, "-")
+	from, err := strconv.Atoi(fromPart)
+	if err != nil {
+		return 0, 0, errors.Errorf(ctx, "invalid value '%s'", fromPart)
+	}
+	to := from
+	if isRange {
+		to, err = strconv.Atoi(toPart)
+		if err != nil {
+			return 0, 0, errors.Errorf(ctx, "invalid value '%s'", toPart)
+		}
+	}
+	if from < minValue || to > maxValue || from > to {
+		return 0, 0, errors.Errorf(ctx, "range '%s' not within %d-%d", part, minValue, maxValue)
+	}
+	return from, to, nil
+}
+
+// matches returns true if the cron fires at the minute of t (in UTC).
+func (c *cronSchedule) matches(t time.Time) bool {
+	t = t.UTC()
+	return c.minutes[t.Minute()] &&
+		c.hours[t.Hour()] &&
+		c.daysOfMonth[t.Day()] &&
+		c.months[int(t.Month())] &&
+		c.daysOfWeek[int(t.Weekday())]
+}
+
+// lastStart returns the latest time the cron fired within (now-duration, now].
+func (c *cronSchedule) lastStart(now time.Time, duration time.Duration) (time.Time, bool) {
+	earliest := now.Add(-duration)
+	for t := now.UTC().Truncate(time.Minute); t.After(earliest); t = t.Add(-time.Minute) {
+		if c.matches(t) {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
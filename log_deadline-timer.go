@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"sync"
+	"time"
+
+	libtime "github.com/bborbe/time"
+)
+
+// deadlineTimer calls fn once currentTimeGetter reaches deadline.
+// The underlying timer runs on the real clock, so on expiry the deadline is checked
+// against currentTimeGetter and the timer is started again if it is not reached yet.
+// This keeps resets consistent with the reset times reported from the same clock.
+type deadlineTimer struct {
+	currentTimeGetter libtime.CurrentTimeGetter
+	deadline          time.Time
+	fn                func()
+
+	mux     sync.Mutex
+	timer   *time.Timer
+	stopped bool
+}
+
+func newDeadlineTimer(
+	currentTimeGetter libtime.CurrentTimeGetter,
+	deadline time.Time,
+	fn func(),
+) *deadlineTimer {
+	d := &deadlineTimer{
+		currentTimeGetter: currentTimeGetter,
+		deadline:          deadline,
+		fn:                fn,
+	}
+	d.start()
+	return d
+}
+
+// Stop prevents fn from being called, if it was not called yet.
+func (d *deadlineTimer) Stop() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	d.stopped = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+}
+
+func (d *deadlineTimer) start() {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	if d.stopped {
+		return
+	}
+	d.timer = time.AfterFunc(d.deadline.Sub(d.currentTimeGetter.Now()), d.expire)
+}
+
+func (d *deadlineTimer) expire() {
+	if d.currentTimeGetter.Now().Before(d.deadline) {
+		d.start()
+		return
+	}
+	d.mux.Lock()
+	stopped := d.stopped
+	d.mux.Unlock()
+
+	if !stopped {
+		d.fn()
+	}
+}
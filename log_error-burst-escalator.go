@@ -0,0 +1,186 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+// ErrorBurstOptions configures NewErrorBurstEscalator.
+type ErrorBurstOptions struct {
+	// Threshold escalates when more than Threshold errors happen within Window.
+	Threshold int
+	// Window is the sliding time window errors are counted in.
+	Window time.Duration
+	// LogLevel is the level the verbosity is raised to.
+	LogLevel glog.Level
+	// Duration is how long the raised level is kept.
+	Duration time.Duration
+	// Cooldown is the time after de-escalation during which no new escalation happens.
+	Cooldown time.Duration
+	// PollInterval is how often Run reads glog's error statistics. Defaults to one second.
+	PollInterval time.Duration
+}
+
+//counterfeiter:generate -o mocks/log-error-burst-escalator.go --fake-name ErrorBurstEscalator . ErrorBurstEscalator
+
+// ErrorBurstEscalator raises the log level for a bounded window when errors pile up,
+// so services collect debug logs about a problem while it happens.
+type ErrorBurstEscalator interface {
+	// RecordError records a single error event.
+	RecordError(ctx context.Context)
+	// Run records every line logged with glog.Error* as error event and de-escalates
+	// in time, until the context is cancelled.
+	Run(ctx context.Context) error
+}
+
+// NewErrorBurstEscalator creates an ErrorBurstEscalator that elevates the log level
+// through the given LogLevelElevator when more than options.Threshold errors happen
+// within options.Window. After options.Duration it de-escalates and waits options.Cooldown
+// before escalating again, so the system does not oscillate.
+//
+// Example (more than 20 errors in 1 minute => v=4 for 5 minutes):
+//
+//	logLevelSetter := log.NewLogLevelSetter(glog.Level(1), 5*time.Minute)
+//	escalator := log.NewErrorBurstEscalator(
+//	    logLevelSetter,
+//	    libtime.NewCurrentTime(),
+//	    log.ErrorBurstOptions{
+//	        Threshold: 20,
+//	        Window:    time.Minute,
+//	        LogLevel:  4,
+//	        Duration:  5 * time.Minute,
+//	        Cooldown:  15 * time.Minute,
+//	    },
+//	)
+//	go escalator.Run(ctx)
+//
+// Escalation and de-escalation are logged as warnings.
+func NewErrorBurstEscalator(
+	logLevelElevator LogLevelElevator,
+	currentTimeGetter libtime.CurrentTimeGetter,
+	options ErrorBurstOptions,
+) ErrorBurstEscalator {
+	if options.PollInterval <= 0 {
+		options.PollInterval = time.Second
+	}
+	return &errorBurstEscalator{
+		logLevelElevator:  logLevelElevator,
+		currentTimeGetter: currentTimeGetter,
+		options:           options,
+	}
+}
+
+type errorBurstEscalator struct {
+	logLevelElevator  LogLevelElevator
+	currentTimeGetter libtime.CurrentTimeGetter
+	options           ErrorBurstOptions
+
+	mux            sync.Mutex
+	events         []errorBurstEvent
+	restore        func()
+	escalatedUntil time.Time
+	cooldownUntil  time.Time
+}
+
+type errorBurstEvent struct {
+	time  time.Time
+	count int64
+}
+
+func (e *errorBurstEscalator) RecordError(ctx context.Context) {
+	e.recordErrors(ctx, 1)
+}
+
+func (e *errorBurstEscalator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.options.PollInterval)
+	defer ticker.Stop()
+	defer e.deescalate()
+
+	lastLines := glog.Stats.Error.Lines()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			lines := glog.Stats.Error.Lines()
+			if lines > lastLines {
+				e.recordErrors(ctx, lines-lastLines)
+			} else {
+				e.recordErrors(ctx, 0)
+			}
+			lastLines = lines
+		}
+	}
+}
+
+func (e *errorBurstEscalator) recordErrors(ctx context.Context, count int64) {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	now := e.currentTimeGetter.Now()
+	if e.restore != nil && !now.Before(e.escalatedUntil) {
+		e.deescalateLocked()
+	}
+
+	if count > 0 {
+		e.events = append(e.events, errorBurstEvent{time: now, count: count})
+	}
+	total := e.pruneLocked(now)
+
+	if e.restore != nil || now.Before(e.cooldownUntil) || total <= int64(e.options.Threshold) {
+		return
+	}
+	glog.Warningf(
+		"error burst detected: %d errors within %v => raise loglevel to %d for %v",
+		total,
+		e.options.Window,
+		e.options.LogLevel,
+		e.options.Duration,
+	)
+	e.restore = e.logLevelElevator.Elevate(ctx, e.options.LogLevel, e.options.Duration)
+	e.escalatedUntil = now.Add(e.options.Duration)
+	e.events = nil
+}
+
+// pruneLocked drops events outside the window and returns the number of remaining errors.
+func (e *errorBurstEscalator) pruneLocked(now time.Time) int64 {
+	windowStart := now.Add(-e.options.Window)
+	var total int64
+	events := e.events[:0]
+	for _, event := range e.events {
+		if event.time.After(windowStart) {
+			events = append(events, event)
+			total += event.count
+		}
+	}
+	e.events = events
+	return total
+}
+
+func (e *errorBurstEscalator) deescalate() {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	e.deescalateLocked()
+}
+
+func (e *errorBurstEscalator) deescalateLocked() {
+	if e.restore == nil {
+		return
+	}
+	e.restore()
+	e.restore = nil
+	e.cooldownUntil = e.escalatedUntil.Add(e.options.Cooldown)
+	glog.Warningf(
+		"error burst escalation ended => loglevel restored, no escalation before %v",
+		e.cooldownUntil.Format(time.RFC3339),
+	)
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log ErrorBurstEscalator", func() {
+	var ctx context.Context
+	var now time.Time
+	var currentTime libtime.CurrentTime
+	var logLevelElevator *mocks.LogLevelElevator
+	var restoreCallCount int
+	var escalator log.ErrorBurstEscalator
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		currentTime = libtime.NewCurrentTime()
+		currentTime.SetNow(now)
+		restoreCallCount = 0
+		logLevelElevator = &mocks.LogLevelElevator{}
+		logLevelElevator.ElevateReturns(func() { restoreCallCount++ })
+		escalator = log.NewErrorBurstEscalator(
+			logLevelElevator,
+			currentTime,
+			log.ErrorBurstOptions{
+				Threshold: 3,
+				Window:    time.Minute,
+				LogLevel:  4,
+				Duration:  5 * time.Minute,
+				Cooldown:  10 * time.Minute,
+			},
+		)
+	})
+
+	recordErrors := func(count int, interval time.Duration) {
+		for i := 0; i < count; i++ {
+			escalator.RecordError(ctx)
+			now = now.Add(interval)
+			currentTime.SetNow(now)
+		}
+	}
+
+	It("does not escalate below threshold", func() {
+		recordErrors(3, time.Second)
+		Expect(logLevelElevator.ElevateCallCount()).To(Equal(0))
+	})
+
+	It("escalates above threshold", func() {
+		recordErrors(4, time.Second)
+		Expect(logLevelElevator.ElevateCallCount()).To(Equal(1))
+		_, level, duration := logLevelElevator.ElevateArgsForCall(0)
+		Expect(level).To(Equal(glog.Level(4)))
+		Expect(duration).To(Equal(5 * time.Minute))
+	})
+
+	It("does not escalate if errors are spread over more than the window", func() {
+		recordErrors(10, 30*time.Second)
+		Expect(logLevelElevator.ElevateCallCount()).To(Equal(0))
+	})
+
+	It("escalates only once while escalated", func() {
+		recordErrors(20, time.Second)
+		Expect(logLevelElevator.ElevateCallCount()).To(Equal(1))
+	})
+
+	It("de-escalates after duration", func() {
+		recordErrors(4, time.Second)
+		now = now.Add(5 * time.Minute)
+		currentTime.SetNow(now)
+		escalator.RecordError(ctx)
+		Expect(restoreCallCount).To(Equal(1))
+	})
+
+	It("does not escalate again during cooldown", func() {
+		recordErrors(4, time.Second)
+		now = now.Add(5 * time.Minute)
+		currentTime.SetNow(now)
+		recordErrors(10, time.Second)
+		Expect(restoreCallCount).To(Equal(1))
+		Expect(logLevelElevator.ElevateCallCount()).To(Equal(1))
+	})
+
+	It("escalates again after cooldown", func() {
+		recordErrors(4, time.Second)
+		now = now.Add(15 * time.Minute)
+		currentTime.SetNow(now)
+		recordErrors(4, time.Second)
+		Expect(restoreCallCount).To(Equal(1))
+		Expect(logLevelElevator.ElevateCallCount()).To(Equal(2))
+	})
+
+	Context("Run", func() {
+		It("records glog errors and de-escalates on cancel", func() {
+			escalator = log.NewErrorBurstEscalator(
+				logLevelElevator,
+				currentTime,
+				log.ErrorBurstOptions{
+					Threshold:    1,
+					Window:       time.Minute,
+					LogLevel:     4,
+					Duration:     5 * time.Minute,
+					PollInterval: 10 * time.Millisecond,
+				},
+			)
+			ctx, cancel := context.WithCancel(ctx)
+			done := make(chan error, 1)
+			go func() {
+				done <- escalator.Run(ctx)
+			}()
+			time.Sleep(20 * time.Millisecond)
+			glog.Error("error burst test 1")
+			glog.Error("error burst test 2")
+			Eventually(logLevelElevator.ElevateCallCount).Should(Equal(1))
+
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+			Expect(restoreCallCount).To(Equal(1))
+		})
+	})
+})
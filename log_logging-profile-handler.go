@@ -0,0 +1,97 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// loggingProfileStateJSON is the JSON representation of a LoggingProfileState.
+type loggingProfileStateJSON struct {
+	Name     string     `json:"name"`
+	Baseline string     `json:"baseline"`
+	RevertAt *time.Time `json:"revertAt,omitempty"`
+	Profiles []string   `json:"profiles"`
+}
+
+func newLoggingProfileStateJSON(state LoggingProfileState) loggingProfileStateJSON {
+	result := loggingProfileStateJSON{
+		Name:     state.Name,
+		Baseline: state.Baseline,
+		Profiles: state.Profiles,
+	}
+	if !state.RevertAt.IsZero() {
+		result.RevertAt = &state.RevertAt
+	}
+	return result
+}
+
+// NewLoggingProfileHandler creates an HTTP handler to switch logging profiles:
+//
+//	GET    - show the active profile and all profile names as JSON
+//	POST   - switch to profile "name" for the optional "duration"
+//	DELETE - revert to the baseline profile
+//
+// The name is read from the path variable "name" or the query parameter "name".
+// POST and DELETE respond with the new state.
+//
+// Example requests:
+//
+//	POST   /debug/profile/investigate?duration=30m
+//	DELETE /debug/profile
+func NewLoggingProfileHandler(loggingProfileManager LoggingProfileManager) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		ctx := WithLogLevelChangeSource(req.Context(), LogLevelChangeSourceHTTP)
+		switch req.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPost, http.MethodPut:
+			duration, err := parseOptionalDuration(ctx, req.URL.Query().Get("duration"))
+			if err != nil {
+				http.Error(resp, err.Error(), http.StatusBadRequest)
+				return
+			}
+			name := requestVar(req, "name")
+			if err := loggingProfileManager.Switch(ctx, name, duration); err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, ErrLoggingProfileNotFound) {
+					status = http.StatusNotFound
+				}
+				http.Error(resp, err.Error(), status)
+				return
+			}
+		case http.MethodDelete:
+			if err := loggingProfileManager.Revert(ctx); err != nil {
+				http.Error(resp, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		default:
+			resp.Header().Set("Allow", "GET, HEAD, POST, PUT, DELETE")
+			http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(
+			resp,
+			req,
+			http.StatusOK,
+			newLoggingProfileStateJSON(loggingProfileManager.State(ctx)),
+		)
+	})
+}
+
+// NewLoggingProfileAdminRoutes returns the routes to switch logging profiles:
+//
+//	GET    {prefix}/profile                       - show active profile
+//	POST   {prefix}/profile/{name}?duration=..  - switch profile
+//	DELETE {prefix}/profile                       - revert to baseline
+func NewLoggingProfileAdminRoutes(loggingProfileManager LoggingProfileManager) AdminRoutes {
+	handler := NewLoggingProfileHandler(loggingProfileManager)
+	return AdminRoutes{
+		{Pattern: "/profile/{name}", Handler: handler},
+		{Pattern: "/profile", Handler: handler},
+	}
+}
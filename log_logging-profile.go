@@ -0,0 +1,338 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	stderrors "errors"
+	"flag"
+	"sync"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+const (
+	// LoggingProfileProd is the name of the profile with v=1 and heavy sampling.
+	LoggingProfileProd = "prod"
+	// LoggingProfileInvestigate is the name of the profile with v=3 and light sampling.
+	LoggingProfileInvestigate = "investigate"
+	// LoggingProfileFirehose is the name of the profile with v=5 and no sampling.
+	LoggingProfileFirehose = "firehose"
+)
+
+// ErrLoggingProfileNotFound is returned by LoggingProfileManager for unknown profile names.
+var ErrLoggingProfileNotFound = stderrors.New("logging profile not found")
+
+// LoggingProfile is a named preset of glog verbosity, vmodule and sampling.
+type LoggingProfile struct {
+	Name string
+	// LogLevel is the glog verbosity (-v) of the profile.
+	LogLevel glog.Level
+	// VModule is the glog vmodule of the profile, empty keeps the initial vmodule.
+	VModule string
+	// SamplerFactory is used for all samplers of the SwitchableSamplerFactory.
+	SamplerFactory SamplerFactory
+}
+
+// Validate checks that the profile has a name and a SamplerFactory.
+func (l LoggingProfile) Validate(ctx context.Context) error {
+	if l.Name == "" {
+		return errors.New(ctx, "name of logging profile missing")
+	}
+	if l.SamplerFactory == nil {
+		return errors.Errorf(ctx, "sampler factory of logging profile '%s' missing", l.Name)
+	}
+	return nil
+}
+
+// DefaultLoggingProfiles returns the profiles "prod", "investigate" and "firehose":
+//
+//	prod         v=1  sample once per 10 seconds or at v>=4 (initial DefaultSamplerFactory)
+//	investigate  v=3  sample once per second or at v>=4
+//	firehose     v=5  sample everything
+func DefaultLoggingProfiles() []LoggingProfile {
+	return []LoggingProfile{
+		{
+			Name:           LoggingProfileProd,
+			LogLevel:       1,
+			SamplerFactory: SamplerFactoryFunc(newDefaultSampler),
+		},
+		{
+			Name:     LoggingProfileInvestigate,
+			LogLevel: 3,
+			SamplerFactory: SamplerFactoryFunc(func() Sampler {
+				return SamplerList{
+					NewSampleTime(time.Second),
+					NewSamplerGlogLevel(4),
+				}
+			}),
+		},
+		{
+			Name:           LoggingProfileFirehose,
+			LogLevel:       5,
+			SamplerFactory: SamplerFactoryFunc(NewSamplerTrue),
+		},
+	}
+}
+
+// LoggingProfileState describes the active profile of a LoggingProfileManager.
+type LoggingProfileState struct {
+	// Name is the name of the active profile.
+	Name string
+	// Baseline is the name of the profile reverted to.
+	Baseline string
+	// RevertAt is the time the active profile is reverted to the baseline,
+	// zero if the baseline is active.
+	RevertAt time.Time
+	// Profiles are the names of all profiles in registration order.
+	Profiles []string
+}
+
+//counterfeiter:generate -o mocks/log-logging-profile-manager.go --fake-name LoggingProfileManager . LoggingProfileManager
+
+// LoggingProfileManager switches between logging profiles and reverts to the baseline
+// profile after a duration.
+type LoggingProfileManager interface {
+	// Switch activates the profile and reverts to the baseline after duration.
+	// A duration <= 0 uses the default duration of the LoggingProfileManager.
+	// Switching to the baseline is the same as Revert.
+	Switch(ctx context.Context, name string, duration time.Duration) error
+	// Revert activates the baseline profile immediately.
+	Revert(ctx context.Context) error
+	// State returns the active profile.
+	State(ctx context.Context) LoggingProfileState
+}
+
+// LoggingProfileManagerOption configures optional features of NewLoggingProfileManager.
+type LoggingProfileManagerOption func(*loggingProfileManager)
+
+// WithLoggingProfileFlagSet changes the FlagSet holding the "vmodule" flag,
+// e.g. for klog-style setups that register the glog flags in their own FlagSet or for
+// tests with an isolated FlagSet. The default is flag.CommandLine.
+func WithLoggingProfileFlagSet(flagSet *flag.FlagSet) LoggingProfileManagerOption {
+	return func(l *loggingProfileManager) {
+		l.flagSet = flagSet
+	}
+}
+
+// NewLoggingProfileManager creates a LoggingProfileManager and activates the baseline.
+// The log level of the baseline must equal the default of logLevelManager, so the
+// baseline is held permanently: it is applied with Reset and not subject to the auto-reset.
+// A switched profile elevates the level for its duration. The vmodule is set on the
+// "vmodule" flag and the SamplerFactory on switchableSamplerFactory.
+//
+// Example:
+//
+//	logLevelSetter := log.NewLogLevelSetter(glog.Level(1), 5*time.Minute)
+//	loggingProfileManager, err := log.NewLoggingProfileManager(
+//	    ctx,
+//	    logLevelSetter,
+//	    log.DefaultSamplerFactory,
+//	    libtime.NewCurrentTime(),
+//	    log.LoggingProfileProd,
+//	    30*time.Minute,
+//	    log.DefaultLoggingProfiles(),
+//	)
+//	if err != nil {
+//	    return err
+//	}
+//	err = loggingProfileManager.Switch(ctx, log.LoggingProfileInvestigate, 0)
+func NewLoggingProfileManager(
+	ctx context.Context,
+	logLevelManager LogLevelManager,
+	switchableSamplerFactory SwitchableSamplerFactory,
+	currentTimeGetter libtime.CurrentTimeGetter,
+	baseline string,
+	defaultDuration time.Duration,
+	profiles []LoggingProfile,
+	options ...LoggingProfileManagerOption,
+) (LoggingProfileManager, error) {
+	profileMap := make(map[string]LoggingProfile, len(profiles))
+	names := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		if err := profile.Validate(ctx); err != nil {
+			return nil, errors.Wrapf(ctx, err, "validate logging profile failed")
+		}
+		if _, ok := profileMap[profile.Name]; ok {
+			return nil, errors.Errorf(ctx, "logging profile '%s' registered twice", profile.Name)
+		}
+		profileMap[profile.Name] = profile
+		names = append(names, profile.Name)
+	}
+	baselineProfile, ok := profileMap[baseline]
+	if !ok {
+		return nil, errors.Wrapf(ctx, ErrLoggingProfileNotFound, "baseline '%s' invalid", baseline)
+	}
+	defaultLogLevel := logLevelManager.State().DefaultLogLevel
+	if baselineProfile.LogLevel != defaultLogLevel {
+		return nil, errors.Errorf(
+			ctx,
+			"log level %d of baseline '%s' differs from default log level %d",
+			baselineProfile.LogLevel,
+			baseline,
+			defaultLogLevel,
+		)
+	}
+	l := &loggingProfileManager{
+		logLevelManager:          logLevelManager,
+		switchableSamplerFactory: switchableSamplerFactory,
+		currentTimeGetter:        currentTimeGetter,
+		baseline:                 baseline,
+		defaultDuration:          defaultDuration,
+		profiles:                 profileMap,
+		names:                    names,
+		flagSet:                  flag.CommandLine,
+	}
+	for _, option := range options {
+		option(l)
+	}
+	vmoduleFlag := l.flagSet.Lookup("vmodule")
+	if vmoduleFlag == nil {
+		return nil, errors.Errorf(ctx, "flag 'vmodule' not found")
+	}
+	l.initialVmodule = vmoduleFlag.Value.String()
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if err := l.activateLocked(ctx, baselineProfile, 0); err != nil {
+		return nil, errors.Wrapf(ctx, err, "activate baseline '%s' failed", baseline)
+	}
+	return l, nil
+}
+
+type loggingProfileManager struct {
+	logLevelManager          LogLevelManager
+	switchableSamplerFactory SwitchableSamplerFactory
+	currentTimeGetter        libtime.CurrentTimeGetter
+	baseline                 string
+	defaultDuration          time.Duration
+	profiles                 map[string]LoggingProfile
+	names                    []string
+	flagSet                  *flag.FlagSet
+	initialVmodule           string
+
+	mux      sync.Mutex
+	active   string
+	revertAt time.Time
+	restore  func()
+	timer    *deadlineTimer
+	// generation invalidates reverts scheduled by earlier switches
+	generation uint64
+}
+
+func (l *loggingProfileManager) Switch(
+	ctx context.Context,
+	name string,
+	duration time.Duration,
+) error {
+	profile, ok := l.profiles[name]
+	if !ok {
+		return errors.Wrapf(ctx, ErrLoggingProfileNotFound, "switch to '%s' failed", name)
+	}
+	if duration <= 0 {
+		duration = l.defaultDuration
+	}
+	if name == l.baseline {
+		duration = 0
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if err := l.activateLocked(ctx, profile, duration); err != nil {
+		return errors.Wrapf(ctx, err, "switch to '%s' failed", name)
+	}
+	return nil
+}
+
+func (l *loggingProfileManager) Revert(ctx context.Context) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if err := l.activateLocked(ctx, l.profiles[l.baseline], 0); err != nil {
+		return errors.Wrapf(ctx, err, "revert to '%s' failed", l.baseline)
+	}
+	return nil
+}
+
+func (l *loggingProfileManager) State(ctx context.Context) LoggingProfileState {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	return LoggingProfileState{
+		Name:     l.active,
+		Baseline: l.baseline,
+		RevertAt: l.revertAt,
+		Profiles: append([]string(nil), l.names...),
+	}
+}
+
+// revert is called by the timer of a switch.
+func (l *loggingProfileManager) revert(generation uint64) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if l.generation != generation {
+		// switched again or reverted in the meantime
+		return
+	}
+	ctx := WithLogLevelChangeSource(context.Background(), LogLevelChangeSourceProgrammatic)
+	if err := l.activateLocked(ctx, l.profiles[l.baseline], 0); err != nil {
+		glog.Warningf("revert logging profile to '%s' failed: %v", l.baseline, err)
+	}
+}
+
+// activateLocked applies the profile and schedules the revert after duration,
+// a duration of zero activates the profile without revert.
+// The caller must hold the mutex.
+func (l *loggingProfileManager) activateLocked(
+	ctx context.Context,
+	profile LoggingProfile,
+	duration time.Duration,
+) error {
+	vmodule := profile.VModule
+	if vmodule == "" {
+		vmodule = l.initialVmodule
+	}
+	if err := l.flagSet.Set("vmodule", vmodule); err != nil {
+		return errors.Wrapf(ctx, err, "set vmodule to '%s' failed", vmodule)
+	}
+	l.switchableSamplerFactory.SetSamplerFactory(profile.SamplerFactory)
+
+	l.generation++
+	if l.timer != nil {
+		l.timer.Stop()
+		l.timer = nil
+	}
+	// apply the level before releasing the previous profile, so it does not drop in between
+	var restore func()
+	l.revertAt = time.Time{}
+	if duration > 0 {
+		restore = l.logLevelManager.Elevate(ctx, profile.LogLevel, duration)
+		l.revertAt = l.currentTimeGetter.Now().Add(duration)
+		generation := l.generation
+		l.timer = newDeadlineTimer(l.currentTimeGetter, l.revertAt, func() {
+			l.revert(generation)
+		})
+	} else if err := l.logLevelManager.Reset(ctx); err != nil {
+		// the baseline level equals the default log level
+		return errors.Wrapf(ctx, err, "reset loglevel failed")
+	}
+	if l.restore != nil {
+		l.restore()
+	}
+	l.restore = restore
+	l.active = profile.Name
+
+	glog.V(2).Infof(
+		"activate logging profile '%s' with v=%d for %v",
+		profile.Name,
+		l.logLevelManager.State().LogLevel,
+		duration,
+	)
+	return nil
+}
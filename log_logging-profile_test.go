@@ -0,0 +1,304 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log LoggingProfileManager", func() {
+	var ctx context.Context
+	var now time.Time
+	var currentTime libtime.CurrentTime
+	var flagSet *flag.FlagSet
+	var logLevelManager *mocks.LogLevelManager
+	var restoreCount *atomic.Int32
+	var switchableSamplerFactory log.SwitchableSamplerFactory
+	var profiles []log.LoggingProfile
+	var loggingProfileManager log.LoggingProfileManager
+	var err error
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		currentTime = libtime.NewCurrentTime()
+		currentTime.SetNow(now)
+		flagSet = flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.String("vmodule", "http=2", "")
+		restoreCount = &atomic.Int32{}
+		logLevelManager = &mocks.LogLevelManager{}
+		logLevelManager.StateReturns(log.LogLevelState{DefaultLogLevel: 1})
+		logLevelManager.ElevateReturns(func() { restoreCount.Add(1) })
+		switchableSamplerFactory = log.NewSwitchableSamplerFactory(
+			log.SamplerFactoryFunc(log.NewSamplerTrue),
+		)
+		profiles = []log.LoggingProfile{
+			{
+				Name:     "quiet",
+				LogLevel: 1,
+				SamplerFactory: log.SamplerFactoryFunc(func() log.Sampler {
+					return log.SamplerFunc(func() bool { return false })
+				}),
+			},
+			{
+				Name:           "loud",
+				LogLevel:       4,
+				VModule:        "kafka=5",
+				SamplerFactory: log.SamplerFactoryFunc(log.NewSamplerTrue),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		loggingProfileManager, err = log.NewLoggingProfileManager(
+			ctx,
+			logLevelManager,
+			switchableSamplerFactory,
+			currentTime,
+			"quiet",
+			time.Hour,
+			profiles,
+			log.WithLoggingProfileFlagSet(flagSet),
+		)
+	})
+
+	It("activates the baseline", func() {
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loggingProfileManager.State(ctx)).To(Equal(log.LoggingProfileState{
+			Name:     "quiet",
+			Baseline: "quiet",
+			Profiles: []string{"quiet", "loud"},
+		}))
+		Expect(switchableSamplerFactory.Sampler().IsSample()).To(BeFalse())
+		Expect(logLevelManager.ElevateCallCount()).To(Equal(0))
+	})
+
+	It("resets the log level for the baseline", func() {
+		Expect(logLevelManager.ResetCallCount()).To(Equal(1))
+		Expect(logLevelManager.SetCallCount()).To(Equal(0))
+		Expect(loggingProfileManager.Switch(ctx, "loud", time.Minute)).To(Succeed())
+		Expect(loggingProfileManager.Revert(ctx)).To(Succeed())
+		Expect(logLevelManager.ResetCallCount()).To(Equal(2))
+	})
+
+	Context("baseline level differs from the default", func() {
+		BeforeEach(func() {
+			logLevelManager.StateReturns(log.LogLevelState{DefaultLogLevel: 3})
+		})
+		It("returns error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(logLevelManager.ResetCallCount()).To(Equal(0))
+			Expect(logLevelManager.SetCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("FlagSet without vmodule", func() {
+		BeforeEach(func() {
+			flagSet = flag.NewFlagSet("test", flag.ContinueOnError)
+		})
+		It("returns error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("setting the baseline level fails", func() {
+		BeforeEach(func() {
+			logLevelManager.ResetReturns(errors.New("banana"))
+		})
+		It("returns error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("switches level, vmodule and samplers", func() {
+		sampler := switchableSamplerFactory.Sampler()
+		Expect(loggingProfileManager.Switch(ctx, "loud", 10*time.Minute)).To(Succeed())
+		Expect(logLevelManager.ElevateCallCount()).To(Equal(1))
+		_, level, duration := logLevelManager.ElevateArgsForCall(0)
+		Expect(level).To(Equal(glog.Level(4)))
+		Expect(duration).To(Equal(10 * time.Minute))
+		Expect(flagSet.Lookup("vmodule").Value.String()).To(Equal("kafka=5"))
+		Expect(sampler.IsSample()).To(BeTrue())
+		state := loggingProfileManager.State(ctx)
+		Expect(state.Name).To(Equal("loud"))
+		Expect(state.RevertAt).To(Equal(now.Add(10 * time.Minute)))
+		Expect(loggingProfileManager.Revert(ctx)).To(Succeed())
+	})
+
+	It("uses the default duration", func() {
+		Expect(loggingProfileManager.Switch(ctx, "loud", 0)).To(Succeed())
+		_, _, duration := logLevelManager.ElevateArgsForCall(0)
+		Expect(duration).To(Equal(time.Hour))
+		Expect(loggingProfileManager.Revert(ctx)).To(Succeed())
+	})
+
+	It("reverts to the baseline", func() {
+		sampler := switchableSamplerFactory.Sampler()
+		Expect(loggingProfileManager.Switch(ctx, "loud", time.Minute)).To(Succeed())
+		Expect(loggingProfileManager.Revert(ctx)).To(Succeed())
+		Expect(restoreCount.Load()).To(Equal(int32(1)))
+		Expect(flagSet.Lookup("vmodule").Value.String()).To(Equal("http=2"))
+		Expect(sampler.IsSample()).To(BeFalse())
+		Expect(loggingProfileManager.State(ctx).RevertAt).To(BeZero())
+	})
+
+	It("reverts on switch to the baseline", func() {
+		Expect(loggingProfileManager.Switch(ctx, "loud", time.Minute)).To(Succeed())
+		Expect(loggingProfileManager.Switch(ctx, "quiet", time.Minute)).To(Succeed())
+		Expect(restoreCount.Load()).To(Equal(int32(1)))
+		Expect(logLevelManager.ElevateCallCount()).To(Equal(1))
+	})
+
+	It("releases the previous elevation on switch", func() {
+		Expect(loggingProfileManager.Switch(ctx, "loud", time.Minute)).To(Succeed())
+		Expect(loggingProfileManager.Switch(ctx, "loud", time.Minute)).To(Succeed())
+		Expect(logLevelManager.ElevateCallCount()).To(Equal(2))
+		Expect(restoreCount.Load()).To(Equal(int32(1)))
+		Expect(loggingProfileManager.Revert(ctx)).To(Succeed())
+	})
+
+	It("reverts automatically after the duration", func() {
+		sampler := switchableSamplerFactory.Sampler()
+		Expect(loggingProfileManager.Switch(ctx, "loud", 50*time.Millisecond)).To(Succeed())
+		currentTime.SetNow(now.Add(50 * time.Millisecond))
+		Eventually(func() string {
+			return loggingProfileManager.State(ctx).Name
+		}).Should(Equal("quiet"))
+		Expect(restoreCount.Load()).To(Equal(int32(1)))
+		Expect(sampler.IsSample()).To(BeFalse())
+	})
+
+	It("returns ErrLoggingProfileNotFound", func() {
+		err := loggingProfileManager.Switch(ctx, "banana", time.Minute)
+		Expect(errors.Is(err, log.ErrLoggingProfileNotFound)).To(BeTrue())
+	})
+
+	Context("unknown baseline", func() {
+		BeforeEach(func() {
+			profiles = profiles[1:]
+		})
+		It("returns error", func() {
+			Expect(errors.Is(err, log.ErrLoggingProfileNotFound)).To(BeTrue())
+		})
+	})
+
+	Context("duplicate profile", func() {
+		BeforeEach(func() {
+			profiles = append(profiles, profiles[0])
+		})
+		It("returns error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("profile without sampler factory", func() {
+		BeforeEach(func() {
+			profiles[1].SamplerFactory = nil
+		})
+		It("returns error", func() {
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	It("provides default profiles", func() {
+		var names []string
+		for _, profile := range log.DefaultLoggingProfiles() {
+			Expect(profile.Validate(ctx)).To(Succeed())
+			names = append(names, profile.Name)
+		}
+		Expect(names).To(Equal([]string{
+			log.LoggingProfileProd,
+			log.LoggingProfileInvestigate,
+			log.LoggingProfileFirehose,
+		}))
+	})
+})
+
+var _ = Describe("Log LoggingProfileHandler", func() {
+	var loggingProfileManager *mocks.LoggingProfileManager
+	var serveMux *http.ServeMux
+	var recorder *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		loggingProfileManager = &mocks.LoggingProfileManager{}
+		loggingProfileManager.StateReturns(log.LoggingProfileState{
+			Name:     "investigate",
+			Baseline: "prod",
+			RevertAt: time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC),
+			Profiles: []string{"prod", "investigate"},
+		})
+		serveMux = http.NewServeMux()
+		log.RegisterAdminRoutes(
+			serveMux,
+			"/debug",
+			log.NewAuthorizerAllowAll(),
+			log.NewLoggingProfileAdminRoutes(loggingProfileManager),
+		)
+		recorder = httptest.NewRecorder()
+	})
+
+	serve := func(method string, target string) {
+		serveMux.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	}
+
+	It("shows the state", func() {
+		serve(http.MethodGet, "/debug/profile")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(`{
+			"name":"investigate",
+			"baseline":"prod",
+			"revertAt":"2026-10-18T02:30:00Z",
+			"profiles":["prod","investigate"]
+		}`))
+	})
+
+	It("switches profile", func() {
+		serve(http.MethodPost, "/debug/profile/investigate?duration=30m")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(loggingProfileManager.SwitchCallCount()).To(Equal(1))
+		ctx, name, duration := loggingProfileManager.SwitchArgsForCall(0)
+		Expect(name).To(Equal("investigate"))
+		Expect(duration).To(Equal(30 * time.Minute))
+		Expect(log.LogLevelChangeSourceFromContext(ctx)).To(Equal(log.LogLevelChangeSourceHTTP))
+	})
+
+	It("returns 404 for unknown profile", func() {
+		loggingProfileManager.SwitchReturns(log.ErrLoggingProfileNotFound)
+		serve(http.MethodPost, "/debug/profile/banana")
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("rejects invalid duration", func() {
+		serve(http.MethodPost, "/debug/profile/investigate?duration=banana")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(loggingProfileManager.SwitchCallCount()).To(Equal(0))
+	})
+
+	It("reverts profile", func() {
+		serve(http.MethodDelete, "/debug/profile")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(loggingProfileManager.RevertCallCount()).To(Equal(1))
+	})
+
+	It("returns revert errors", func() {
+		loggingProfileManager.RevertReturns(errors.New("banana"))
+		serve(http.MethodDelete, "/debug/profile")
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+	})
+})
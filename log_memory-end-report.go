@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// MemoryEndGC selects the garbage collection MemoryMonitor runs after the end checkpoint
+// to measure the reclaimable memory.
+type MemoryEndGC string
+
+const (
+	// MemoryEndGCOff skips the garbage collection.
+	MemoryEndGCOff MemoryEndGC = "off"
+	// MemoryEndGCSync runs runtime.GC, which blocks until the collection is complete.
+	MemoryEndGCSync MemoryEndGC = "gc"
+	// MemoryEndGCFreeOSMemory runs debug.FreeOSMemory, which additionally returns as much
+	// memory as possible to the OS.
+	MemoryEndGCFreeOSMemory MemoryEndGC = "free-os-memory"
+)
+
+// run collects garbage and reports whether it did.
+func (m MemoryEndGC) run() bool {
+	switch m {
+	case MemoryEndGCSync:
+		runtime.GC()
+		return true
+	case MemoryEndGCFreeOSMemory:
+		debug.FreeOSMemory()
+		return true
+	default:
+		return false
+	}
+}
+
+// MemoryReclaimed holds the memory released by the end-of-run garbage collection.
+// Positive values were reclaimed.
+type MemoryReclaimed struct {
+	// GC is the collection that was run.
+	GC MemoryEndGC `json:"gc"`
+	// Duration of the collection.
+	Duration time.Duration `json:"duration"`
+	// Alloc is the number of heap bytes freed.
+	Alloc int64 `json:"alloc"`
+	// HeapInuse is the number of in-use heap span bytes freed.
+	HeapInuse int64 `json:"heapInuse"`
+	// HeapObjects is the number of heap objects freed.
+	HeapObjects int64 `json:"heapObjects"`
+	// Sys is the number of bytes returned to the OS.
+	Sys int64 `json:"sys"`
+	// RSS is the decrease of the resident set size, zero if unknown.
+	RSS int64 `json:"rss,omitempty"`
+}
+
+// MarshalJSON encodes Duration as Go duration string.
+func (m MemoryReclaimed) MarshalJSON() ([]byte, error) {
+	type memoryReclaimed MemoryReclaimed
+	return json.Marshal(struct {
+		memoryReclaimed
+		Duration string `json:"duration"`
+	}{
+		memoryReclaimed: memoryReclaimed(m),
+		Duration:        m.Duration.String(),
+	})
+}
+
+// newMemoryReclaimed returns the decrease from before to after.
+func newMemoryReclaimed(
+	memoryEndGC MemoryEndGC,
+	duration time.Duration,
+	before MemorySnapshot,
+	after MemorySnapshot,
+	rssBefore uint64,
+	rssAfter uint64,
+) MemoryReclaimed {
+	diff := after.Diff(before)
+	result := MemoryReclaimed{
+		GC:          memoryEndGC,
+		Duration:    duration,
+		Alloc:       -diff.Alloc,
+		HeapInuse:   -diff.HeapInuse,
+		HeapObjects: -diff.HeapObjects,
+		Sys:         int64(before.Sys) - int64(after.Sys),
+	}
+	if rssBefore > 0 && rssAfter > 0 {
+		result.RSS = int64(rssBefore) - int64(rssAfter)
+	}
+	return result
+}
+
+// String formats the reclaimed memory for log lines.
+func (m MemoryReclaimed) String() string {
+	result := fmt.Sprintf(
+		"Reclaimed by %s in %v: Alloc: %.2f MB, HeapInUse: %.2f MB, HeapObjects: %d, "+
+			"Sys: %.2f MB",
+		m.GC,
+		m.Duration.Round(time.Microsecond),
+		float64(m.Alloc)/1024/1024,
+		float64(m.HeapInuse)/1024/1024,
+		m.HeapObjects,
+		float64(m.Sys)/1024/1024,
+	)
+	if m.RSS != 0 {
+		result += fmt.Sprintf(", RSS: %.2f MB", float64(m.RSS)/1024/1024)
+	}
+	return result
+}
+
+// MemoryEndReport is the result of MemoryMonitor.EndReport.
+type MemoryEndReport struct {
+	// Snapshot of the end checkpoint.
+	Snapshot MemorySnapshot
+	// ContainerMemory of the end checkpoint, empty if unknown.
+	ContainerMemory ContainerMemory
+	// Reclaimed is the memory released by the end-of-run GC, nil if it is off.
+	Reclaimed *MemoryReclaimed
+	// Phases are the stats of all ended phases.
+	Phases MemoryPhaseSummary
+}
+
+// String formats the report with one line per section followed by the phase table.
+func (m MemoryEndReport) String() string {
+	lines := []string{fmt.Sprintf("MEMORY REPORT - END - %s", m.Snapshot)}
+	if limits := memoryLimits(m.Snapshot, m.ContainerMemory); limits != "" {
+		lines = append(lines, fmt.Sprintf("MEMORY REPORT - LIMITS - %s", limits))
+	}
+	if m.Reclaimed != nil {
+		lines = append(lines, fmt.Sprintf("MEMORY REPORT - GC - %s", *m.Reclaimed))
+	}
+	if len(m.Phases) > 0 {
+		lines = append(lines, "MEMORY REPORT - PHASES", m.Phases.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log MemoryEndReport", func() {
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+	var containerMemoryReader *mocks.ContainerMemoryReader
+	var memoryEndGC log.MemoryEndGC
+	var buffer *bytes.Buffer
+	var memoryMonitor log.MemoryMonitor
+
+	BeforeEach(func() {
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memorySnapshotReader.ReadMemorySnapshotReturnsOnCall(0, log.MemorySnapshot{
+			Time:        time.Now(),
+			Alloc:       100 * mb,
+			HeapInuse:   120 * mb,
+			HeapObjects: 5000,
+			Sys:         200 * mb,
+		})
+		memorySnapshotReader.ReadMemorySnapshotReturnsOnCall(1, log.MemorySnapshot{
+			Time:        time.Now(),
+			Alloc:       40 * mb,
+			HeapInuse:   50 * mb,
+			HeapObjects: 2000,
+			Sys:         180 * mb,
+		})
+		containerMemoryReader = &mocks.ContainerMemoryReader{}
+		containerMemoryReader.ReadContainerMemoryReturnsOnCall(0, log.ContainerMemory{
+			RSS: 150 * mb,
+		}, nil)
+		containerMemoryReader.ReadContainerMemoryReturnsOnCall(1, log.ContainerMemory{
+			RSS: 110 * mb,
+		}, nil)
+		memoryEndGC = log.MemoryEndGCSync
+		buffer = &bytes.Buffer{}
+	})
+
+	JustBeforeEach(func() {
+		memoryMonitor = log.NewMemoryMonitor(
+			time.Hour,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(containerMemoryReader),
+			log.WithMemoryEndGC(memoryEndGC),
+			log.WithMemoryEndReportWriter(buffer),
+		)
+	})
+
+	It("reports the reclaimed memory as delta", func() {
+		report := memoryMonitor.EndReport()
+		Expect(report.Snapshot.Alloc).To(Equal(uint64(100 * mb)))
+		Expect(report.Reclaimed).NotTo(BeNil())
+		Expect(report.Reclaimed.GC).To(Equal(log.MemoryEndGCSync))
+		Expect(report.Reclaimed.Alloc).To(Equal(int64(60 * mb)))
+		Expect(report.Reclaimed.HeapInuse).To(Equal(int64(70 * mb)))
+		Expect(report.Reclaimed.HeapObjects).To(Equal(int64(3000)))
+		Expect(report.Reclaimed.Sys).To(Equal(int64(20 * mb)))
+		Expect(report.Reclaimed.RSS).To(Equal(int64(40 * mb)))
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(2))
+	})
+
+	It("writes the report", func() {
+		report := memoryMonitor.EndReport()
+		Expect(buffer.String()).To(Equal(report.String() + "\n"))
+		Expect(buffer.String()).To(ContainSubstring("MEMORY REPORT - END - Alloc: 100.00 MB"))
+		Expect(buffer.String()).To(ContainSubstring("MEMORY REPORT - LIMITS - RSS: 150.00 MB"))
+		Expect(buffer.String()).To(ContainSubstring(
+			"Alloc: 60.00 MB, HeapInUse: 70.00 MB, HeapObjects: 3000, Sys: 20.00 MB, RSS: 40.00 MB",
+		))
+	})
+
+	It("does not block", func() {
+		start := time.Now()
+		memoryMonitor.LogMemoryUsageOnEnd()
+		Expect(time.Since(start)).To(BeNumerically("<", 100*time.Millisecond))
+	})
+
+	It("includes the phases", func() {
+		memoryMonitor.Begin("load").End()
+		Expect(memoryMonitor.EndReport().Phases).To(HaveLen(1))
+		Expect(buffer.String()).To(ContainSubstring("MEMORY REPORT - PHASES\nPHASE"))
+	})
+
+	Context("free OS memory", func() {
+		BeforeEach(func() {
+			memoryEndGC = log.MemoryEndGCFreeOSMemory
+		})
+
+		It("reports the reclaimed memory", func() {
+			report := memoryMonitor.EndReport()
+			Expect(report.Reclaimed).NotTo(BeNil())
+			Expect(report.Reclaimed.GC).To(Equal(log.MemoryEndGCFreeOSMemory))
+		})
+	})
+
+	Context("off", func() {
+		BeforeEach(func() {
+			memoryEndGC = log.MemoryEndGCOff
+		})
+
+		It("skips the GC", func() {
+			report := memoryMonitor.EndReport()
+			Expect(report.Reclaimed).To(BeNil())
+			Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(1))
+			Expect(buffer.String()).NotTo(ContainSubstring("MEMORY REPORT - GC"))
+		})
+	})
+})
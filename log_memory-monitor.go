@@ -5,11 +5,13 @@
 package log
 
 import (
+	"context"
 	"fmt"
-	"runtime"
+	"io"
 	"sync"
 	"time"
 
+	"github.com/bborbe/errors"
 	libtime "github.com/bborbe/time"
 	"github.com/golang/glog"
 )
@@ -22,41 +24,235 @@ type MemoryMonitor interface {
 	LogMemoryUsagef(format string, args ...interface{})
 	LogMemoryUsageOnStart()
 	LogMemoryUsageOnEnd()
+	// Begin starts the phase name and records the memory allocated until End is called.
+	// LogMemoryUsageOnEnd logs a summary of all phases.
+	//
+	//	phase := memoryMonitor.Begin("load")
+	//	defer phase.End()
+	Begin(name string) MemoryPhase
+	// Phases returns the stats of all ended phases summed up by name.
+	Phases() MemoryPhaseSummary
+	// Checkpoints returns the most recent logged checkpoints, oldest first.
+	Checkpoints() []MemoryCheckpoint
+	// EndReport logs the end checkpoint, runs the end-of-run GC and returns the report.
+	// LogMemoryUsageOnEnd is EndReport without result.
+	EndReport() MemoryEndReport
+	// Run logs the memory usage on start, every log interval and a final report once ctx
+	// is cancelled. The signature matches bborbe/run style functions.
+	Run(ctx context.Context) error
 }
 
-// NewMemoryMonitor creates a new memory monitor that logs memory usage at specified intervals
-func NewMemoryMonitor(logInterval time.Duration) MemoryMonitor {
-	return &memoryMonitor{
-		logInterval: logInterval,
-		lastLogTime: time.Time{}, // zero time initially
+// DefaultMemoryCheckpointHistory is the number of checkpoints kept by MemoryMonitor.
+const DefaultMemoryCheckpointHistory = 60
+
+// MemoryCheckpoint holds the values logged by a MemoryMonitor checkpoint.
+type MemoryCheckpoint struct {
+	// Name of the checkpoint, e.g. "START", "PERIODIC" or the name passed to LogMemoryUsage.
+	Name            string          `json:"name"`
+	Snapshot        MemorySnapshot  `json:"runtime"`
+	ContainerMemory ContainerMemory `json:"container,omitzero"`
+}
+
+// MemoryMonitorOption configures the MemoryMonitor created by NewMemoryMonitor.
+type MemoryMonitorOption func(*memoryMonitor)
+
+// WithMemorySnapshotReader sets the MemorySnapshotReader used for every checkpoint.
+// The default is NewRuntimeMetricsReader, use NewMemStatsReader for runtime.ReadMemStats.
+func WithMemorySnapshotReader(memorySnapshotReader MemorySnapshotReader) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.memorySnapshotReader = memorySnapshotReader
 	}
 }
 
-type memoryMonitor struct {
-	logInterval time.Duration
+// WithMemoryThresholds sets thresholds that log a warning at every checkpoint crossing them.
+func WithMemoryThresholds(memoryThresholds MemoryThresholds) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.memoryThresholds = memoryThresholds
+	}
+}
 
-	mutex       sync.Mutex
-	lastLogTime time.Time
+// WithMemoryProfileWriter writes profiles with memoryProfileWriter whenever a threshold
+// set by WithMemoryThresholds is crossed.
+func WithMemoryProfileWriter(memoryProfileWriter MemoryProfileWriter) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.memoryProfileWriter = memoryProfileWriter
+	}
 }
 
-// LogMemoryUsage logs current memory usage if enough time has passed since last log (thread-safe)
-func (m *memoryMonitor) LogMemoryUsage(name string) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+// WithContainerMemoryReader sets the ContainerMemoryReader used to log the cgroup usage,
+// limit and RSS next to the runtime statistics. The default reads DefaultCgroupPath,
+// DefaultProcCgroupPath and DefaultProcStatusPath, nil disables it.
+func WithContainerMemoryReader(containerMemoryReader ContainerMemoryReader) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.containerMemoryReader = containerMemoryReader
+	}
+}
 
-	now := libtime.Now()
+// WithMemoryTrendAnalyzer adds the heap after GC of every checkpoint to memoryTrendAnalyzer
+// and logs a warning with the estimated time until the memory limit is reached while the
+// growth is sustained.
+func WithMemoryTrendAnalyzer(memoryTrendAnalyzer MemoryTrendAnalyzer) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.memoryTrendAnalyzer = memoryTrendAnalyzer
+	}
+}
 
-	// Check if enough time has passed since last log
-	if m.lastLogTime.IsZero() || now.Sub(m.lastLogTime) >= m.logInterval {
-		// Update the last log time first
-		m.lastLogTime = now
+// WithMemoryEndGC selects the garbage collection run after the end checkpoint to report the
+// reclaimed memory. The default is MemoryEndGCSync: EndReport blocks until the collection is
+// complete, because the reclaimed memory is only known afterwards. It runs once at the end,
+// so the pause is bounded by a single GC. MemoryEndGCOff skips it, e.g. for fast shutdowns.
+func WithMemoryEndGC(memoryEndGC MemoryEndGC) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.memoryEndGC = memoryEndGC
+	}
+}
 
-		// Then log the memory usage
-		MemoryStats(name)
+// WithMemoryEndReportWriter writes the end report to writer in addition to the log.
+func WithMemoryEndReportWriter(writer io.Writer) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.endReportWriter = writer
 	}
 }
 
-// LogMemoryUsagef logs current memory usage with formatted message if enough time has passed since last log (thread-safe)
+// WithMemoryCheckpointHistory sets the number of checkpoints returned by Checkpoints.
+// The default is DefaultMemoryCheckpointHistory, zero disables the history.
+func WithMemoryCheckpointHistory(size int) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.checkpointHistory = size
+	}
+}
+
+// WithMemoryCurrentTimeGetter changes the clock used to measure the end-of-run GC.
+// The default is libtime.NewCurrentTime.
+func WithMemoryCurrentTimeGetter(currentTimeGetter libtime.CurrentTimeGetter) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.currentTimeGetter = currentTimeGetter
+	}
+}
+
+// WithMemoryRunInterval sets the interval of the periodic checkpoints of Run.
+// NewMemoryMonitor defaults to its log interval, the Sampler based constructors require it.
+func WithMemoryRunInterval(runInterval time.Duration) MemoryMonitorOption {
+	return func(m *memoryMonitor) {
+		m.runInterval = runInterval
+	}
+}
+
+// NewMemoryMonitor creates a new memory monitor that logs memory usage at specified intervals.
+// Memory statistics are read with runtime/metrics, which does not stop the world.
+// The cgroup usage and limit, RSS and the utilization of the limits are logged as well.
+func NewMemoryMonitor(logInterval time.Duration, options ...MemoryMonitorOption) MemoryMonitor {
+	return newMemoryMonitor(
+		NewSampleTime(logInterval),
+		append([]MemoryMonitorOption{WithMemoryRunInterval(logInterval)}, options...)...,
+	)
+}
+
+// NewMemoryMonitorWithSampler creates a memory monitor that logs the checkpoints of
+// LogMemoryUsage selected by sampler, e.g. NewSampleMod or a SamplerList.
+// Start, end and phases are always logged.
+//
+// Example:
+//
+//	memoryMonitor := log.NewMemoryMonitorWithSampler(
+//	    log.SamplerList{log.NewSampleMod(1000), log.NewSamplerGlogLevel(3)},
+//	    log.WithMemoryRunInterval(time.Minute),
+//	)
+func NewMemoryMonitorWithSampler(
+	sampler Sampler,
+	options ...MemoryMonitorOption,
+) MemoryMonitor {
+	return newMemoryMonitor(sampler, options...)
+}
+
+// NewMemoryMonitorWithSamplerFactory creates a memory monitor like NewMemoryMonitorWithSampler
+// with a sampler of samplerFactory. With DefaultSamplerFactory or another
+// SwitchableSamplerFactory memory logging follows reconfigurations at runtime, e.g. by
+// logging profiles.
+func NewMemoryMonitorWithSamplerFactory(
+	samplerFactory SamplerFactory,
+	options ...MemoryMonitorOption,
+) MemoryMonitor {
+	return newMemoryMonitor(samplerFactory.Sampler(), options...)
+}
+
+func newMemoryMonitor(sampler Sampler, options ...MemoryMonitorOption) *memoryMonitor {
+	m := &memoryMonitor{
+		sampler:              sampler,
+		memorySnapshotReader: NewRuntimeMetricsReader(),
+		containerMemoryReader: NewContainerMemoryReader(
+			DefaultCgroupPath,
+			DefaultProcCgroupPath,
+			DefaultProcStatusPath,
+		),
+		memoryEndGC:       MemoryEndGCSync,
+		currentTimeGetter: libtime.NewCurrentTime(),
+		checkpointHistory: DefaultMemoryCheckpointHistory,
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// Run logs memory usage every run interval in a background loop until ctx is cancelled
+// and returns nil after the final report. The periodic checkpoints are not sampled.
+//
+// Example:
+//
+//	memoryMonitor := log.NewMemoryMonitor(time.Minute)
+//	go func() {
+//	    _ = memoryMonitor.Run(ctx)
+//	}()
+func (m *memoryMonitor) Run(ctx context.Context) error {
+	if m.runInterval <= 0 {
+		return errors.Errorf(ctx, "run interval %v must be positive", m.runInterval)
+	}
+	m.LogMemoryUsageOnStart()
+
+	ticker := time.NewTicker(m.runInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			m.LogMemoryUsageOnEnd()
+			return nil
+		case <-ticker.C:
+			m.logMemoryUsage("PERIODIC")
+		}
+	}
+}
+
+type memoryMonitor struct {
+	sampler               Sampler
+	runInterval           time.Duration
+	memorySnapshotReader  MemorySnapshotReader
+	containerMemoryReader ContainerMemoryReader
+	memoryThresholds      MemoryThresholds
+	memoryProfileWriter   MemoryProfileWriter
+	memoryTrendAnalyzer   MemoryTrendAnalyzer
+	memoryEndGC           MemoryEndGC
+	endReportWriter       io.Writer
+	checkpointHistory     int
+	currentTimeGetter     libtime.CurrentTimeGetter
+
+	memoryPhases memoryPhases
+
+	mutex sync.Mutex
+	// lastSnapshot is the snapshot of the previous checkpoint, zero before the first one
+	lastSnapshot MemorySnapshot
+	checkpoints  []MemoryCheckpoint
+}
+
+// LogMemoryUsage logs current memory usage if the sampler selects it (thread-safe)
+func (m *memoryMonitor) LogMemoryUsage(name string) {
+	if !m.sampler.IsSample() {
+		return
+	}
+	m.logMemoryUsage(name)
+}
+
+// LogMemoryUsagef logs current memory usage with formatted message if the sampler selects it (thread-safe)
 func (m *memoryMonitor) LogMemoryUsagef(format string, args ...interface{}) {
 	name := fmt.Sprintf(format, args...)
 	m.LogMemoryUsage(name)
@@ -65,16 +261,211 @@ func (m *memoryMonitor) LogMemoryUsagef(format string, args ...interface{}) {
 // LogMemoryUsageOnStart logs memory usage at the beginning
 func (m *memoryMonitor) LogMemoryUsageOnStart() {
 	glog.Infof("MEMORY MONITOR - Started")
-	MemoryStats("START")
+	m.logMemoryUsage("START")
 }
 
-// LogMemoryUsageOnEnd logs memory usage at the end
+// LogMemoryUsageOnEnd logs memory usage, the summary of all phases and the memory reclaimed
+// by the end-of-run GC at the end
 func (m *memoryMonitor) LogMemoryUsageOnEnd() {
+	m.EndReport()
+}
+
+// EndReport logs the end checkpoint, the summary of all phases and the memory reclaimed by
+// the garbage collection selected with WithMemoryEndGC and returns them.
+func (m *memoryMonitor) EndReport() MemoryEndReport {
 	glog.Infof("MEMORY MONITOR - Completed")
-	MemoryStats("END")
 
-	// Force garbage collection and log again to see the difference
-	runtime.GC()
-	time.Sleep(100 * time.Millisecond) // Give GC a moment to complete
-	MemoryStats("after GC")
+	checkpoint := m.logMemoryUsage("END")
+
+	report := MemoryEndReport{
+		Snapshot:        checkpoint.Snapshot,
+		ContainerMemory: checkpoint.ContainerMemory,
+	}
+	report.Phases = m.Phases()
+	if len(report.Phases) > 0 {
+		glog.Infof("MEMORY PHASES - Summary\n%s", report.Phases)
+	}
+
+	// The collection runs without holding the mutex, so concurrent checkpoints are not blocked.
+	start := m.currentTimeGetter.Now()
+	if m.memoryEndGC.run() {
+		duration := m.currentTimeGetter.Now().Sub(start)
+		after := m.memorySnapshotReader.ReadMemorySnapshot()
+		reclaimed := newMemoryReclaimed(
+			m.memoryEndGC,
+			duration,
+			report.Snapshot,
+			after,
+			report.ContainerMemory.RSS,
+			m.readContainerMemory().RSS,
+		)
+		report.Reclaimed = &reclaimed
+		glog.Infof("MEMORY USAGE - after GC - %s", reclaimed)
+
+		m.mutex.Lock()
+		m.lastSnapshot = after
+		m.mutex.Unlock()
+	}
+
+	if m.endReportWriter != nil {
+		if _, err := fmt.Fprintln(m.endReportWriter, report); err != nil {
+			glog.Warningf("write memory end report failed: %v", err)
+		}
+	}
+	return report
+}
+
+// Begin starts a phase, its stats are independent of the log interval.
+func (m *memoryMonitor) Begin(name string) MemoryPhase {
+	m.memoryPhases.begin(name)
+	return &memoryPhase{
+		name:                 name,
+		begin:                m.memorySnapshotReader.ReadMemorySnapshot(),
+		memorySnapshotReader: m.memorySnapshotReader,
+		memoryPhases:         &m.memoryPhases,
+	}
+}
+
+// Phases returns the stats of all ended phases in the order they were first begun.
+func (m *memoryMonitor) Phases() MemoryPhaseSummary {
+	return m.memoryPhases.ended()
+}
+
+// logMemoryUsage logs the checkpoint and writes profiles if a threshold is crossed.
+// Profiles are written without holding the mutex, so other checkpoints are not blocked.
+func (m *memoryMonitor) logMemoryUsage(name string) MemoryCheckpoint {
+	m.mutex.Lock()
+	checkpoint, crossed := m.logMemoryUsageLocked(name)
+	m.mutex.Unlock()
+
+	if crossed {
+		m.writeProfiles(name)
+	}
+	return checkpoint
+}
+
+// logMemoryUsageLocked logs the absolute values, the utilization of the memory limits and the
+// changes since the previous checkpoint and returns the checkpoint and whether a threshold
+// is crossed. The caller must hold the mutex.
+func (m *memoryMonitor) logMemoryUsageLocked(name string) (MemoryCheckpoint, bool) {
+	snapshot := m.memorySnapshotReader.ReadMemorySnapshot()
+	containerMemory := m.readContainerMemory()
+
+	line := fmt.Sprintf("MEMORY USAGE - %s - %s", name, snapshot)
+	if limits := memoryLimits(snapshot, containerMemory); limits != "" {
+		line += " - " + limits
+	}
+	if !m.lastSnapshot.Time.IsZero() {
+		line += fmt.Sprintf(" - Delta: %s", snapshot.Diff(m.lastSnapshot))
+	}
+	glog.Info(line)
+
+	limit := m.memoryLimit(snapshot, containerMemory)
+	crossed := m.checkThresholdsLocked(name, snapshot, containerMemory, limit)
+	m.checkTrend(name, snapshot, limit)
+	checkpoint := MemoryCheckpoint{
+		Name:            name,
+		Snapshot:        snapshot,
+		ContainerMemory: containerMemory,
+	}
+	m.addCheckpointLocked(checkpoint)
+	m.lastSnapshot = snapshot
+	return checkpoint, crossed
+}
+
+// addCheckpointLocked adds the checkpoint to the history and drops the oldest.
+// The caller must hold the mutex.
+func (m *memoryMonitor) addCheckpointLocked(checkpoint MemoryCheckpoint) {
+	if m.checkpointHistory <= 0 {
+		return
+	}
+	m.checkpoints = append(m.checkpoints, checkpoint)
+	if len(m.checkpoints) > m.checkpointHistory {
+		m.checkpoints = m.checkpoints[len(m.checkpoints)-m.checkpointHistory:]
+	}
+}
+
+// Checkpoints returns a copy of the checkpoint history.
+func (m *memoryMonitor) Checkpoints() []MemoryCheckpoint {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return append([]MemoryCheckpoint(nil), m.checkpoints...)
+}
+
+// memoryLimit returns the limit of the thresholds, GOMEMLIMIT or the container memory limit,
+// zero if none is known.
+func (m *memoryMonitor) memoryLimit(
+	snapshot MemorySnapshot,
+	containerMemory ContainerMemory,
+) uint64 {
+	if limit := m.memoryThresholds.limit(snapshot); limit > 0 {
+		return limit
+	}
+	return containerMemory.Limit
+}
+
+// checkTrend adds the snapshot to the trend analyzer and warns about sustained growth.
+func (m *memoryMonitor) checkTrend(name string, snapshot MemorySnapshot, limit uint64) {
+	if m.memoryTrendAnalyzer == nil {
+		return
+	}
+	m.memoryTrendAnalyzer.AddSample(snapshot)
+	trend := m.memoryTrendAnalyzer.Trend(limit)
+	if trend.Sustained {
+		glog.Warningf("MEMORY TREND - %s - %s", name, trend)
+	} else {
+		glog.V(2).Infof("MEMORY TREND - %s - %s", name, trend)
+	}
+}
+
+// readContainerMemory returns the container memory, empty if disabled or unreadable.
+func (m *memoryMonitor) readContainerMemory() ContainerMemory {
+	if m.containerMemoryReader == nil {
+		return ContainerMemory{}
+	}
+	containerMemory, err := m.containerMemoryReader.ReadContainerMemory(context.Background())
+	if err != nil {
+		glog.V(2).Infof("read container memory failed: %v", err)
+		return ContainerMemory{}
+	}
+	return containerMemory
+}
+
+// checkThresholdsLocked warns about crossed thresholds and returns whether any is crossed.
+// Without Limit and GOMEMLIMIT the limit percent refers to the container memory limit.
+// The RSS is used as memory in use if known, otherwise the memory retained from the OS.
+// The caller must hold the mutex.
+func (m *memoryMonitor) checkThresholdsLocked(
+	name string,
+	snapshot MemorySnapshot,
+	containerMemory ContainerMemory,
+	limit uint64,
+) bool {
+	usage := containerMemory.RSS
+	if usage == 0 {
+		usage = snapshot.Retained()
+	}
+	memoryThresholds := m.memoryThresholds
+	memoryThresholds.Limit = limit
+	violations := memoryThresholds.check(snapshot, m.lastSnapshot, usage)
+	for _, violation := range violations {
+		glog.Warningf("MEMORY THRESHOLD - %s - %s", name, violation)
+	}
+	return len(violations) > 0
+}
+
+// writeProfiles writes profiles with the MemoryProfileWriter, if configured.
+func (m *memoryMonitor) writeProfiles(name string) {
+	if m.memoryProfileWriter == nil {
+		return
+	}
+	paths, err := m.memoryProfileWriter.WriteProfiles(context.Background())
+	if err != nil {
+		glog.Warningf("MEMORY THRESHOLD - %s - write profiles failed: %v", name, err)
+		return
+	}
+	if len(paths) > 0 {
+		glog.Warningf("MEMORY THRESHOLD - %s - profiles written to %s", name, paths)
+	}
 }
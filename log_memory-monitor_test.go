@@ -0,0 +1,142 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log MemoryMonitor", func() {
+	var ctx context.Context
+	var cancel context.CancelFunc
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	Context("Run", func() {
+		It("runs until the context is cancelled", func() {
+			memoryMonitor := log.NewMemoryMonitor(10 * time.Millisecond)
+			runErr := make(chan error, 1)
+			go func() {
+				runErr <- memoryMonitor.Run(ctx)
+			}()
+			Consistently(runErr, 50*time.Millisecond).ShouldNot(Receive())
+			cancel()
+			Eventually(runErr).Should(Receive(BeNil()))
+		})
+
+		It("returns error for a non-positive interval", func() {
+			Expect(log.NewMemoryMonitor(0).Run(ctx)).NotTo(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Log MemoryMonitor with Sampler", func() {
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+	var sampler *mocks.LogSampler
+
+	BeforeEach(func() {
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{Time: time.Now()})
+		sampler = &mocks.LogSampler{}
+	})
+
+	It("logs checkpoints selected by the sampler", func() {
+		memoryMonitor := log.NewMemoryMonitorWithSampler(
+			sampler,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(nil),
+		)
+		memoryMonitor.LogMemoryUsage("skipped")
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(0))
+
+		sampler.IsSampleReturns(true)
+		memoryMonitor.LogMemoryUsagef("sampled %d", 1)
+		Expect(sampler.IsSampleCallCount()).To(Equal(2))
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(1))
+	})
+
+	It("always logs start and end", func() {
+		memoryMonitor := log.NewMemoryMonitorWithSampler(
+			sampler,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(nil),
+			log.WithMemoryEndGC(log.MemoryEndGCOff),
+		)
+		memoryMonitor.LogMemoryUsageOnStart()
+		memoryMonitor.LogMemoryUsageOnEnd()
+		Expect(sampler.IsSampleCallCount()).To(Equal(0))
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(2))
+	})
+
+	It("follows reconfigurations of a SwitchableSamplerFactory", func() {
+		switchableSamplerFactory := log.NewSwitchableSamplerFactory(
+			log.SamplerFactoryFunc(func() log.Sampler {
+				return log.SamplerFunc(func() bool { return false })
+			}),
+		)
+		memoryMonitor := log.NewMemoryMonitorWithSamplerFactory(
+			switchableSamplerFactory,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(nil),
+		)
+		memoryMonitor.LogMemoryUsage("skipped")
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(0))
+
+		switchableSamplerFactory.SetSamplerFactory(log.SamplerFactoryFunc(log.NewSamplerTrue))
+		memoryMonitor.LogMemoryUsage("sampled")
+		memoryMonitor.LogMemoryUsage("sampled again")
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(2))
+	})
+
+	Context("Run", func() {
+		var ctx context.Context
+		var cancel context.CancelFunc
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(context.Background())
+		})
+
+		AfterEach(func() {
+			cancel()
+		})
+
+		It("requires a run interval", func() {
+			memoryMonitor := log.NewMemoryMonitorWithSampler(sampler)
+			Expect(memoryMonitor.Run(ctx)).NotTo(Succeed())
+		})
+
+		It("logs periodic checkpoints regardless of the sampler", func() {
+			memoryMonitor := log.NewMemoryMonitorWithSampler(
+				sampler,
+				log.WithMemorySnapshotReader(memorySnapshotReader),
+				log.WithContainerMemoryReader(nil),
+				log.WithMemoryEndGC(log.MemoryEndGCOff),
+				log.WithMemoryRunInterval(time.Millisecond),
+			)
+			runErr := make(chan error, 1)
+			go func() {
+				runErr <- memoryMonitor.Run(ctx)
+			}()
+			Eventually(memorySnapshotReader.ReadMemorySnapshotCallCount).
+				Should(BeNumerically(">=", 3))
+			cancel()
+			Eventually(runErr).Should(Receive(BeNil()))
+			Expect(sampler.IsSampleCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+//counterfeiter:generate -o mocks/log-memory-phase.go --fake-name MemoryPhase . MemoryPhase
+
+// MemoryPhase is a named section of a run started with MemoryMonitor.Begin.
+type MemoryPhase interface {
+	// End records the memory allocated since Begin and returns it.
+	// Only the first call records, further calls return the same stats.
+	End() MemoryPhaseStats
+}
+
+// MemoryPhaseStats holds the memory accounting of a phase. The diff fields are summed up
+// over all runs of phases with the same name.
+type MemoryPhaseStats struct {
+	// Name of the phase.
+	Name string
+	// Count is the number of ended runs of the phase.
+	Count int
+	MemorySnapshotDiff
+}
+
+// MemoryPhaseSummary holds the stats of all phases in the order they were first begun.
+type MemoryPhaseSummary []MemoryPhaseStats
+
+// String formats the summary as table.
+func (m MemoryPhaseSummary) String() string {
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "PHASE\tCOUNT\tWALL\tALLOCATED\tOBJECTS\tGC\tPAUSE")
+	for _, stats := range m {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%d\t%v\t%.2f MB\t%d\t%d\t%v\n",
+			stats.Name,
+			stats.Count,
+			stats.Duration.Round(time.Millisecond),
+			toMB(stats.AllocatedBytes),
+			stats.AllocatedObjects,
+			stats.NumGC,
+			stats.PauseTotal,
+		)
+	}
+	_ = writer.Flush()
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+// memoryPhases aggregates the stats of ended phases by name.
+type memoryPhases struct {
+	mux     sync.Mutex
+	indexes map[string]int
+	summary MemoryPhaseSummary
+}
+
+// begin reserves the row of the phase, so the summary follows the order of Begin.
+func (m *memoryPhases) begin(name string) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if _, ok := m.indexes[name]; ok {
+		return
+	}
+	if m.indexes == nil {
+		m.indexes = make(map[string]int)
+	}
+	m.indexes[name] = len(m.summary)
+	m.summary = append(m.summary, MemoryPhaseStats{Name: name})
+}
+
+func (m *memoryPhases) end(name string, diff MemorySnapshotDiff) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	stats := &m.summary[m.indexes[name]]
+	stats.Count++
+	stats.Duration += diff.Duration
+	stats.AllocatedBytes += diff.AllocatedBytes
+	stats.AllocatedObjects += diff.AllocatedObjects
+	stats.FreedObjects += diff.FreedObjects
+	stats.NumGC += diff.NumGC
+	stats.PauseTotal += diff.PauseTotal
+	stats.Alloc += diff.Alloc
+	stats.HeapInuse += diff.HeapInuse
+	stats.HeapObjects += diff.HeapObjects
+}
+
+// ended returns the stats of all phases ended at least once.
+func (m *memoryPhases) ended() MemoryPhaseSummary {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var result MemoryPhaseSummary
+	for _, stats := range m.summary {
+		if stats.Count > 0 {
+			result = append(result, stats)
+		}
+	}
+	return result
+}
+
+type memoryPhase struct {
+	name                 string
+	begin                MemorySnapshot
+	memorySnapshotReader MemorySnapshotReader
+	memoryPhases         *memoryPhases
+
+	once  sync.Once
+	stats MemoryPhaseStats
+}
+
+func (m *memoryPhase) End() MemoryPhaseStats {
+	m.once.Do(func() {
+		diff := m.memorySnapshotReader.ReadMemorySnapshot().Diff(m.begin)
+		m.memoryPhases.end(m.name, diff)
+		m.stats = MemoryPhaseStats{Name: m.name, Count: 1, MemorySnapshotDiff: diff}
+		glog.V(2).Infof("MEMORY PHASE - %s - %s", m.name, diff)
+	})
+	return m.stats
+}
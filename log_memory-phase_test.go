@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log MemoryPhase", func() {
+	var now time.Time
+	var totalAlloc uint64
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+	var memoryMonitor log.MemoryMonitor
+
+	BeforeEach(func() {
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		totalAlloc = 0
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memorySnapshotReader.ReadMemorySnapshotStub = func() log.MemorySnapshot {
+			return log.MemorySnapshot{
+				Time:       now,
+				TotalAlloc: totalAlloc,
+				Mallocs:    totalAlloc / 1024,
+				NumGC:      uint32(totalAlloc / mb),
+			}
+		}
+		memoryMonitor = log.NewMemoryMonitor(
+			time.Hour,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(nil),
+		)
+	})
+
+	run := func(name string, allocated uint64, duration time.Duration) log.MemoryPhaseStats {
+		phase := memoryMonitor.Begin(name)
+		totalAlloc += allocated
+		now = now.Add(duration)
+		return phase.End()
+	}
+
+	It("records a phase", func() {
+		stats := run("load", 10*mb, time.Second)
+		Expect(stats.Name).To(Equal("load"))
+		Expect(stats.Count).To(Equal(1))
+		Expect(stats.Duration).To(Equal(time.Second))
+		Expect(stats.AllocatedBytes).To(Equal(uint64(10 * mb)))
+		Expect(stats.AllocatedObjects).To(Equal(uint64(10 * 1024)))
+		Expect(stats.NumGC).To(Equal(uint32(10)))
+	})
+
+	It("records End only once", func() {
+		phase := memoryMonitor.Begin("load")
+		totalAlloc += mb
+		first := phase.End()
+		totalAlloc += mb
+		Expect(phase.End()).To(Equal(first))
+		Expect(memoryMonitor.Phases()).To(HaveLen(1))
+		Expect(memoryMonitor.Phases()[0].AllocatedBytes).To(Equal(uint64(mb)))
+	})
+
+	It("sums up phases by name in the order of Begin", func() {
+		transform := memoryMonitor.Begin("transform")
+		run("load", 2*mb, time.Second)
+		run("load", 3*mb, 2*time.Second)
+		transform.End()
+
+		summary := memoryMonitor.Phases()
+		Expect(summary).To(HaveLen(2))
+		Expect(summary[0].Name).To(Equal("transform"))
+		Expect(summary[0].Count).To(Equal(1))
+		Expect(summary[0].AllocatedBytes).To(Equal(uint64(5 * mb)))
+		Expect(summary[1].Name).To(Equal("load"))
+		Expect(summary[1].Count).To(Equal(2))
+		Expect(summary[1].Duration).To(Equal(3 * time.Second))
+		Expect(summary[1].AllocatedBytes).To(Equal(uint64(5 * mb)))
+	})
+
+	It("excludes phases not ended yet", func() {
+		memoryMonitor.Begin("running")
+		Expect(memoryMonitor.Phases()).To(BeEmpty())
+	})
+
+	It("formats the summary as table", func() {
+		run("load", 10*mb, time.Second)
+		run("transform", mb/2, 1500*time.Millisecond)
+		Expect(memoryMonitor.Phases().String()).To(Equal(
+			"PHASE      COUNT  WALL  ALLOCATED  OBJECTS  GC  PAUSE\n" +
+				"load       1      1s    10.00 MB   10240    10  0s\n" +
+				"transform  1      1.5s  0.50 MB    512      0   0s",
+		))
+	})
+
+	It("logs the summary on end", func() {
+		run("load", mb, time.Second)
+		memoryMonitor.LogMemoryUsageOnEnd()
+		Expect(memoryMonitor.Phases()).To(HaveLen(1))
+	})
+})
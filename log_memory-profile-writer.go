@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+//counterfeiter:generate -o mocks/log-memory-profile-writer.go --fake-name MemoryProfileWriter . MemoryProfileWriter
+
+// MemoryProfileWriter writes a heap profile and a goroutine dump for later analysis.
+type MemoryProfileWriter interface {
+	// WriteProfiles writes the profiles and returns the paths of the written files.
+	// It returns no paths if the last profiles were written too recently.
+	WriteProfiles(ctx context.Context) ([]string, error)
+}
+
+// memoryProfile is a runtime/pprof profile written by memoryProfileWriter.
+type memoryProfile struct {
+	// name of the runtime/pprof profile
+	name string
+	// debug parameter of pprof.Profile.WriteTo
+	debug  int
+	prefix string
+	suffix string
+}
+
+var memoryProfiles = []memoryProfile{
+	{name: "heap", debug: 0, prefix: "heap-", suffix: ".pb.gz"},
+	{name: "goroutine", debug: 2, prefix: "goroutine-", suffix: ".txt"},
+}
+
+// NewMemoryProfileWriter creates a MemoryProfileWriter that writes "heap-<time>.pb.gz"
+// (readable with go tool pprof) and "goroutine-<time>.txt" into dir.
+// Profiles are written at most once per minInterval and only the newest retain files of
+// each kind are kept, older ones are deleted.
+//
+// Example:
+//
+//	memoryMonitor := log.NewMemoryMonitor(
+//	    time.Minute,
+//	    log.WithMemoryThresholds(log.MemoryThresholds{LimitPercent: 90}),
+//	    log.WithMemoryProfileWriter(log.NewMemoryProfileWriter(
+//	        libtime.NewCurrentTime(),
+//	        "/tmp/profiles",
+//	        10*time.Minute,
+//	        3,
+//	    )),
+//	)
+func NewMemoryProfileWriter(
+	currentTimeGetter libtime.CurrentTimeGetter,
+	dir string,
+	minInterval time.Duration,
+	retain int,
+) MemoryProfileWriter {
+	return &memoryProfileWriter{
+		currentTimeGetter: currentTimeGetter,
+		dir:               dir,
+		minInterval:       minInterval,
+		retain:            retain,
+	}
+}
+
+type memoryProfileWriter struct {
+	currentTimeGetter libtime.CurrentTimeGetter
+	dir               string
+	minInterval       time.Duration
+	retain            int
+
+	mux       sync.Mutex
+	lastWrite time.Time
+}
+
+func (m *memoryProfileWriter) WriteProfiles(ctx context.Context) ([]string, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	now := m.currentTimeGetter.Now()
+	if !m.lastWrite.IsZero() && now.Sub(m.lastWrite) < m.minInterval {
+		glog.V(2).Infof("skip write memory profiles, last write at %v", m.lastWrite)
+		return nil, nil
+	}
+	m.lastWrite = now
+
+	if err := os.MkdirAll(m.dir, 0o750); err != nil {
+		return nil, errors.Wrapf(ctx, err, "create directory %s failed", m.dir)
+	}
+	timestamp := now.UTC().Format("20060102T150405.000Z")
+	paths := make([]string, 0, len(memoryProfiles))
+	for _, profile := range memoryProfiles {
+		path := filepath.Join(m.dir, profile.prefix+timestamp+profile.suffix)
+		if err := writeMemoryProfile(ctx, profile, path); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+		if err := m.cleanup(ctx, profile); err != nil {
+			return paths, err
+		}
+	}
+	return paths, nil
+}
+
+// cleanup deletes all but the newest retain files of the profile.
+func (m *memoryProfileWriter) cleanup(ctx context.Context, profile memoryProfile) error {
+	if m.retain <= 0 {
+		return nil
+	}
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "read directory %s failed", m.dir)
+	}
+	var names []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, profile.prefix) && strings.HasSuffix(name, profile.suffix) {
+			names = append(names, name)
+		}
+	}
+	// the timestamp format sorts chronologically
+	sort.Strings(names)
+	for len(names) > m.retain {
+		path := filepath.Join(m.dir, names[0])
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(ctx, err, "remove %s failed", path)
+		}
+		names = names[1:]
+	}
+	return nil
+}
+
+func writeMemoryProfile(ctx context.Context, profile memoryProfile, path string) error {
+	file, err := os.Create(path) // #nosec G304 -- path is built from the configured directory
+	if err != nil {
+		return errors.Wrapf(ctx, err, "create %s failed", path)
+	}
+	defer file.Close()
+
+	if err := pprof.Lookup(profile.name).WriteTo(file, profile.debug); err != nil {
+		return errors.Wrapf(ctx, err, "write %s profile to %s failed", profile.name, path)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(ctx, err, "close %s failed", path)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"math"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	libtime "github.com/bborbe/time"
+)
+
+//counterfeiter:generate -o mocks/log-memory-snapshot-reader.go --fake-name MemorySnapshotReader . MemorySnapshotReader
+
+// MemorySnapshotReader reads the current memory statistics of the process.
+type MemorySnapshotReader interface {
+	// ReadMemorySnapshot returns the current memory statistics.
+	ReadMemorySnapshot() MemorySnapshot
+}
+
+// MemorySnapshotReaderFunc is a function type that implements the MemorySnapshotReader interface.
+type MemorySnapshotReaderFunc func() MemorySnapshot
+
+// ReadMemorySnapshot implements the MemorySnapshotReader interface by calling the function.
+func (m MemorySnapshotReaderFunc) ReadMemorySnapshot() MemorySnapshot {
+	return m()
+}
+
+// NewMemStatsReader returns a MemorySnapshotReader based on runtime.ReadMemStats,
+// see CaptureMemorySnapshot.
+func NewMemStatsReader() MemorySnapshotReader {
+	return MemorySnapshotReaderFunc(CaptureMemorySnapshot)
+}
+
+// indexes of the samples read by runtimeMetricsReader
+const (
+	metricHeapObjectsBytes = iota
+	metricHeapUnusedBytes
+	metricTotalBytes
+	metricAllocsBytes
+	metricAllocsObjects
+	metricFreesObjects
+	metricHeapObjects
+	metricGCCycles
+	metricGCPauses
+	metricScanHeapBytes
+	metricGCCPUSeconds
+	metricTotalCPUSeconds
+	metricGoroutines
+	metricMemoryLimit
+	metricHeapLiveBytes
+	metricHeapReleasedBytes
+)
+
+// runtimeMetricNames are the runtime/metrics names in the order of the indexes above.
+var runtimeMetricNames = []string{
+	metricHeapObjectsBytes:  "/memory/classes/heap/objects:bytes",
+	metricHeapUnusedBytes:   "/memory/classes/heap/unused:bytes",
+	metricTotalBytes:        "/memory/classes/total:bytes",
+	metricAllocsBytes:       "/gc/heap/allocs:bytes",
+	metricAllocsObjects:     "/gc/heap/allocs:objects",
+	metricFreesObjects:      "/gc/heap/frees:objects",
+	metricHeapObjects:       "/gc/heap/objects:objects",
+	metricGCCycles:          "/gc/cycles/total:gc-cycles",
+	metricGCPauses:          "/sched/pauses/total/gc:seconds",
+	metricScanHeapBytes:     "/gc/scan/heap:bytes",
+	metricGCCPUSeconds:      "/cpu/classes/gc/total:cpu-seconds",
+	metricTotalCPUSeconds:   "/cpu/classes/total:cpu-seconds",
+	metricGoroutines:        "/sched/goroutines:goroutines",
+	metricMemoryLimit:       "/gc/gomemlimit:bytes",
+	metricHeapLiveBytes:     "/gc/heap/live:bytes",
+	metricHeapReleasedBytes: "/memory/classes/heap/released:bytes",
+}
+
+// NewRuntimeMetricsReader returns a MemorySnapshotReader based on runtime/metrics.
+// Unlike runtime.ReadMemStats it does not stop the world, which makes it cheap enough
+// for hot loops. PauseTotal is estimated from the GC pause histogram and GCCPUFraction
+// from the CPU time estimates of the runtime, which are updated with every GC cycle.
+func NewRuntimeMetricsReader() MemorySnapshotReader {
+	samples := make([]metrics.Sample, len(runtimeMetricNames))
+	for i, name := range runtimeMetricNames {
+		samples[i].Name = name
+	}
+	return &runtimeMetricsReader{
+		samples: samples,
+	}
+}
+
+type runtimeMetricsReader struct {
+	// mux guards samples, which are reused to avoid allocations
+	mux     sync.Mutex
+	samples []metrics.Sample
+}
+
+func (r *runtimeMetricsReader) ReadMemorySnapshot() MemorySnapshot {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	metrics.Read(r.samples)
+	value := func(index int) metrics.Value {
+		return r.samples[index].Value
+	}
+
+	snapshot := MemorySnapshot{
+		Time:          libtime.Now(),
+		Alloc:         metricUint64(value(metricHeapObjectsBytes)),
+		TotalAlloc:    metricUint64(value(metricAllocsBytes)),
+		Sys:           metricUint64(value(metricTotalBytes)),
+		HeapObjects:   metricUint64(value(metricHeapObjects)),
+		Mallocs:       metricUint64(value(metricAllocsObjects)),
+		Frees:         metricUint64(value(metricFreesObjects)),
+		NumGC:         uint32(metricUint64(value(metricGCCycles))), // #nosec G115 -- like MemStats
+		PauseTotal:    metricHistogramSum(value(metricGCPauses)),
+		ScannableHeap: metricUint64(value(metricScanHeapBytes)),
+		Goroutines:    metricUint64(value(metricGoroutines)),
+		MemoryLimit:   metricUint64(value(metricMemoryLimit)),
+		HeapLive:      metricUint64(value(metricHeapLiveBytes)),
+		HeapReleased:  metricUint64(value(metricHeapReleasedBytes)),
+	}
+	snapshot.HeapInuse = snapshot.Alloc + metricUint64(value(metricHeapUnusedBytes))
+	if total := metricFloat64(value(metricTotalCPUSeconds)); total > 0 {
+		snapshot.GCCPUFraction = metricFloat64(value(metricGCCPUSeconds)) / total
+	}
+	return snapshot
+}
+
+// metricUint64 returns the value of a uint64 metric, zero if unsupported by the runtime.
+func metricUint64(value metrics.Value) uint64 {
+	if value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return value.Uint64()
+}
+
+// metricFloat64 returns the value of a float64 metric, zero if unsupported by the runtime.
+func metricFloat64(value metrics.Value) float64 {
+	if value.Kind() != metrics.KindFloat64 {
+		return 0
+	}
+	return value.Float64()
+}
+
+// metricHistogramSum estimates the sum of a histogram in seconds by the bucket midpoints.
+func metricHistogramSum(value metrics.Value) time.Duration {
+	if value.Kind() != metrics.KindFloat64Histogram {
+		return 0
+	}
+	histogram := value.Float64Histogram()
+	var sum float64
+	for i, count := range histogram.Counts {
+		if count == 0 {
+			continue
+		}
+		lower, upper := histogram.Buckets[i], histogram.Buckets[i+1]
+		switch {
+		case math.IsInf(lower, -1):
+			sum += float64(count) * upper
+		case math.IsInf(upper, 1):
+			sum += float64(count) * lower
+		default:
+			sum += float64(count) * (lower + upper) / 2
+		}
+	}
+	return time.Duration(sum * float64(time.Second))
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log MemorySnapshotReader", func() {
+	Context("NewRuntimeMetricsReader", func() {
+		var snapshot log.MemorySnapshot
+
+		BeforeEach(func() {
+			runtime.GC()
+			snapshot = log.NewRuntimeMetricsReader().ReadMemorySnapshot()
+		})
+
+		It("reads the values of ReadMemStats", func() {
+			memStats := log.NewMemStatsReader().ReadMemorySnapshot()
+			Expect(snapshot.Time).NotTo(BeZero())
+			Expect(snapshot.Alloc).To(BeNumerically(">", 0))
+			Expect(snapshot.HeapInuse).To(BeNumerically(">=", snapshot.Alloc))
+			Expect(snapshot.Sys).To(BeNumerically(">", 0))
+			Expect(snapshot.TotalAlloc).To(BeNumerically(">=", snapshot.Alloc))
+			Expect(snapshot.HeapObjects).To(BeNumerically(">", 0))
+			Expect(snapshot.Mallocs).To(BeNumerically(">=", snapshot.Frees))
+			Expect(snapshot.NumGC).To(BeNumerically(">=", 1))
+			Expect(snapshot.NumGC).To(BeNumerically("<=", memStats.NumGC))
+		})
+
+		It("reads the values missing in ReadMemStats", func() {
+			Expect(snapshot.ScannableHeap).To(BeNumerically(">", 0))
+			Expect(snapshot.Goroutines).To(BeNumerically(">", 0))
+			Expect(snapshot.MemoryLimit).To(BeNumerically(">", 0))
+			Expect(snapshot.HeapLive).To(BeNumerically(">", 0))
+			Expect(snapshot.GCCPUFraction).To(BeNumerically(">=", 0))
+			Expect(snapshot.GCCPUFraction).To(BeNumerically("<=", 1))
+		})
+	})
+
+	Context("MemoryMonitor", func() {
+		var memorySnapshotReader *mocks.MemorySnapshotReader
+		var memoryMonitor log.MemoryMonitor
+
+		BeforeEach(func() {
+			memorySnapshotReader = &mocks.MemorySnapshotReader{}
+			memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{Time: time.Now()})
+			memoryMonitor = log.NewMemoryMonitor(
+				time.Hour,
+				log.WithMemorySnapshotReader(memorySnapshotReader),
+			)
+		})
+
+		It("reads snapshots with the given reader", func() {
+			memoryMonitor.LogMemoryUsage("first")
+			memoryMonitor.LogMemoryUsage("within interval")
+			Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(1))
+		})
+	})
+})
+
+func BenchmarkMemStatsReader(b *testing.B) {
+	memorySnapshotReader := log.NewMemStatsReader()
+	b.ReportAllocs()
+	for b.Loop() {
+		memorySnapshotReader.ReadMemorySnapshot()
+	}
+}
+
+func BenchmarkRuntimeMetricsReader(b *testing.B) {
+	memorySnapshotReader := log.NewRuntimeMetricsReader()
+	b.ReportAllocs()
+	for b.Loop() {
+		memorySnapshotReader.ReadMemorySnapshot()
+	}
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+// memoryStats is the response of GET, Checkpoints is omitted without MemoryMonitor.
+type memoryStats struct {
+	Runtime     MemorySnapshot     `json:"runtime"`
+	Container   ContainerMemory    `json:"container,omitzero"`
+	Checkpoints []MemoryCheckpoint `json:"checkpoints,omitempty"`
+}
+
+// memoryGC is the response of POST.
+type memoryGC struct {
+	Before    memoryStats     `json:"before"`
+	After     memoryStats     `json:"after"`
+	Reclaimed MemoryReclaimed `json:"reclaimed"`
+}
+
+// NewMemoryStatsHandler creates an HTTP handler for memory statistics:
+//
+//	GET  - current runtime and container memory and the checkpoint history of memoryMonitor
+//	POST - run the garbage collection "gc" (runtime.GC, default) or "free-os-memory"
+//	       (debug.FreeOSMemory) and respond with the numbers before and after
+//
+// The collection is read from the path variable "gc" or the query parameter "gc".
+// POST requests must pass authorizer, GET requests are not authorized by the handler.
+// containerMemoryReader may be nil to omit the container memory and memoryMonitor may be
+// nil to omit the checkpoints.
+//
+// Example:
+//
+//	router.Handle("/debug/memory", log.NewMemoryStatsHandler(
+//	    libtime.NewCurrentTime(),
+//	    log.NewRuntimeMetricsReader(),
+//	    log.NewContainerMemoryReader(
+//	        log.DefaultCgroupPath,
+//	        log.DefaultProcCgroupPath,
+//	        log.DefaultProcStatusPath,
+//	    ),
+//	    memoryMonitor,
+//	    log.NewAuthorizerBearerToken(token),
+//	))
+func NewMemoryStatsHandler(
+	currentTimeGetter libtime.CurrentTimeGetter,
+	memorySnapshotReader MemorySnapshotReader,
+	containerMemoryReader ContainerMemoryReader,
+	memoryMonitor MemoryMonitor,
+	authorizer Authorizer,
+) http.Handler {
+	statsHandler := newMemoryStatsHandler(
+		memorySnapshotReader,
+		containerMemoryReader,
+		memoryMonitor,
+	)
+	gcHandler := newMemoryGCHandler(
+		currentTimeGetter,
+		memorySnapshotReader,
+		containerMemoryReader,
+		authorizer,
+	)
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet, http.MethodHead:
+			statsHandler.ServeHTTP(resp, req)
+		case http.MethodPost:
+			gcHandler.ServeHTTP(resp, req)
+		default:
+			resp.Header().Set("Allow", "GET, HEAD, POST")
+			http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// NewMemoryStatsAdminRoutes returns the routes for memory statistics:
+//
+//	GET  {prefix}/memory             - show runtime, container and checkpoint history
+//	POST {prefix}/memory/gc/{gc}     - run "gc" or "free-os-memory"
+//	POST {prefix}/memory/gc          - run runtime.GC
+//
+// Other methods are rejected with 405 Method Not Allowed. containerMemoryReader and
+// memoryMonitor may be nil like for NewMemoryStatsHandler.
+// RegisterAdminRoutes authorizes all requests, so POST is not authorized again.
+func NewMemoryStatsAdminRoutes(
+	currentTimeGetter libtime.CurrentTimeGetter,
+	memorySnapshotReader MemorySnapshotReader,
+	containerMemoryReader ContainerMemoryReader,
+	memoryMonitor MemoryMonitor,
+) AdminRoutes {
+	gcHandler := newMemoryGCHandler(
+		currentTimeGetter,
+		memorySnapshotReader,
+		containerMemoryReader,
+		NewAuthorizerAllowAll(),
+	)
+	return AdminRoutes{
+		{Pattern: "/memory/gc/{gc}", Handler: gcHandler},
+		{Pattern: "/memory/gc", Handler: gcHandler},
+		{Pattern: "/memory", Handler: newMemoryStatsHandler(
+			memorySnapshotReader,
+			containerMemoryReader,
+			memoryMonitor,
+		)},
+	}
+}
+
+// newMemoryStatsHandler responds to GET and HEAD with the current memory and the
+// checkpoints of memoryMonitor.
+func newMemoryStatsHandler(
+	memorySnapshotReader MemorySnapshotReader,
+	containerMemoryReader ContainerMemoryReader,
+	memoryMonitor MemoryMonitor,
+) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			resp.Header().Set("Allow", "GET, HEAD")
+			http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		result, err := readMemoryStats(req.Context(), memorySnapshotReader, containerMemoryReader)
+		if err != nil {
+			http.Error(resp, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if memoryMonitor != nil {
+			result.Checkpoints = memoryMonitor.Checkpoints()
+		}
+		writeJSON(resp, req, http.StatusOK, result)
+	})
+}
+
+// newMemoryGCHandler runs the garbage collection on authorized POST requests and responds
+// with the memory before and after.
+func newMemoryGCHandler(
+	currentTimeGetter libtime.CurrentTimeGetter,
+	memorySnapshotReader MemorySnapshotReader,
+	containerMemoryReader ContainerMemoryReader,
+	authorizer Authorizer,
+) http.Handler {
+	gcHandler := NewAuthorizedHandler(authorizer, http.HandlerFunc(
+		func(resp http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			memoryEndGC := MemoryEndGC(requestVar(req, "gc"))
+			switch memoryEndGC {
+			case "":
+				memoryEndGC = MemoryEndGCSync
+			case MemoryEndGCSync, MemoryEndGCFreeOSMemory:
+			default:
+				http.Error(resp, "invalid gc, use gc or free-os-memory", http.StatusBadRequest)
+				return
+			}
+			before, err := readMemoryStats(ctx, memorySnapshotReader, containerMemoryReader)
+			if err != nil {
+				http.Error(resp, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			start := currentTimeGetter.Now()
+			memoryEndGC.run()
+			duration := currentTimeGetter.Now().Sub(start)
+			after, err := readMemoryStats(ctx, memorySnapshotReader, containerMemoryReader)
+			if err != nil {
+				http.Error(resp, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			reclaimed := newMemoryReclaimed(
+				memoryEndGC,
+				duration,
+				before.Runtime,
+				after.Runtime,
+				before.Container.RSS,
+				after.Container.RSS,
+			)
+			glog.Infof("MEMORY GC - http - %s", reclaimed)
+			writeJSON(resp, req, http.StatusOK, memoryGC{
+				Before:    before,
+				After:     after,
+				Reclaimed: reclaimed,
+			})
+		},
+	))
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			resp.Header().Set("Allow", "POST")
+			http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		gcHandler.ServeHTTP(resp, req)
+	})
+}
+
+// readMemoryStats reads the runtime and container memory.
+// Without containerMemoryReader the container memory stays empty and is omitted.
+func readMemoryStats(
+	ctx context.Context,
+	memorySnapshotReader MemorySnapshotReader,
+	containerMemoryReader ContainerMemoryReader,
+) (memoryStats, error) {
+	snapshot := memorySnapshotReader.ReadMemorySnapshot()
+	if containerMemoryReader == nil {
+		return memoryStats{Runtime: snapshot}, nil
+	}
+	containerMemory, err := containerMemoryReader.ReadContainerMemory(ctx)
+	if err != nil {
+		return memoryStats{}, errors.Wrapf(ctx, err, "read container memory failed")
+	}
+	return memoryStats{
+		Runtime:   snapshot,
+		Container: containerMemory,
+	}, nil
+}
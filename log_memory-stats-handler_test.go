@@ -0,0 +1,308 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log MemoryStatsHandler", func() {
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+	var containerMemoryReader *mocks.ContainerMemoryReader
+	var memoryMonitor *mocks.MemoryMonitor
+	var handler http.Handler
+	var resp *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		now := time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memorySnapshotReader.ReadMemorySnapshotReturnsOnCall(0, log.MemorySnapshot{
+			Time:        now,
+			Alloc:       100 * mb,
+			HeapObjects: 5000,
+			Sys:         200 * mb,
+			PauseTotal:  3 * time.Millisecond,
+			MemoryLimit: 400 * mb,
+		})
+		memorySnapshotReader.ReadMemorySnapshotReturnsOnCall(1, log.MemorySnapshot{
+			Time:        now,
+			Alloc:       40 * mb,
+			HeapObjects: 2000,
+			Sys:         200 * mb,
+			MemoryLimit: math.MaxInt64,
+		})
+		containerMemoryReader = &mocks.ContainerMemoryReader{}
+		containerMemoryReader.ReadContainerMemoryReturns(log.ContainerMemory{
+			CgroupVersion: 2,
+			Limit:         500 * mb,
+			Usage:         250 * mb,
+		}, nil)
+		memoryMonitor = &mocks.MemoryMonitor{}
+		memoryMonitor.CheckpointsReturns([]log.MemoryCheckpoint{
+			{Name: "START", Snapshot: log.MemorySnapshot{Time: now, Alloc: mb}},
+		})
+		handler = log.NewMemoryStatsHandler(
+			libtime.NewCurrentTime(),
+			memorySnapshotReader,
+			containerMemoryReader,
+			memoryMonitor,
+			log.NewAuthorizerBearerToken("secret"),
+		)
+		resp = httptest.NewRecorder()
+	})
+
+	serve := func(method string, target string) map[string]interface{} {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer secret")
+		handler.ServeHTTP(resp, req)
+		if resp.Header().Get("Content-Type") != "application/json" {
+			return nil
+		}
+		var result map[string]interface{}
+		Expect(json.Unmarshal(resp.Body.Bytes(), &result)).To(Succeed())
+		return result
+	}
+
+	It("returns runtime, container and checkpoints", func() {
+		result := serve(http.MethodGet, "/debug/memory")
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(result).To(HaveKeyWithValue("runtime", SatisfyAll(
+			HaveKeyWithValue("alloc", BeNumerically("==", 100*mb)),
+			HaveKeyWithValue("pauseTotal", "3ms"),
+			HaveKeyWithValue("memoryLimit", BeNumerically("==", 400*mb)),
+			HaveKeyWithValue("memoryLimitPercent", BeNumerically("==", 50)),
+			HaveKeyWithValue("heapReleased", BeNumerically("==", 0)),
+		)))
+		Expect(result).To(HaveKeyWithValue("container", SatisfyAll(
+			HaveKeyWithValue("cgroupVersion", BeNumerically("==", 2)),
+			HaveKeyWithValue("limit", BeNumerically("==", 500*mb)),
+			HaveKeyWithValue("usagePercent", BeNumerically("==", 50)),
+		)))
+		Expect(result).To(HaveKeyWithValue("checkpoints", ConsistOf(SatisfyAll(
+			HaveKeyWithValue("name", "START"),
+			HaveKeyWithValue("runtime", HaveKeyWithValue("alloc", BeNumerically("==", mb))),
+			Not(HaveKey("container")),
+		))))
+	})
+
+	It("omits unknown values", func() {
+		handler = log.NewMemoryStatsHandler(
+			libtime.NewCurrentTime(),
+			log.MemorySnapshotReaderFunc(func() log.MemorySnapshot {
+				return log.MemorySnapshot{MemoryLimit: math.MaxInt64}
+			}),
+			log.NewContainerMemoryReader(
+				"testdata/missing",
+				"testdata/proc/missing",
+				"testdata/proc/missing",
+			),
+			nil,
+			log.NewAuthorizerBearerToken("secret"),
+		)
+		result := serve(http.MethodGet, "/debug/memory")
+		Expect(result).NotTo(HaveKey("container"))
+		Expect(result).NotTo(HaveKey("checkpoints"))
+		Expect(result).To(HaveKeyWithValue("runtime", Not(HaveKey("memoryLimit"))))
+	})
+
+	Context("without ContainerMemoryReader", func() {
+		BeforeEach(func() {
+			handler = log.NewMemoryStatsHandler(
+				libtime.NewCurrentTime(),
+				memorySnapshotReader,
+				nil,
+				nil,
+				log.NewAuthorizerBearerToken("secret"),
+			)
+		})
+		It("omits the container", func() {
+			result := serve(http.MethodGet, "/debug/memory")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(result).To(HaveKey("runtime"))
+			Expect(result).NotTo(HaveKey("container"))
+		})
+		It("runs GC", func() {
+			result := serve(http.MethodPost, "/debug/memory/gc")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(result).To(HaveKeyWithValue("before", Not(HaveKey("container"))))
+			Expect(result).To(HaveKeyWithValue("after", Not(HaveKey("container"))))
+		})
+	})
+
+	It("runs GC and returns before and after", func() {
+		result := serve(http.MethodPost, "/debug/memory/gc")
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(result).To(HaveKeyWithValue("before",
+			HaveKeyWithValue("runtime", HaveKeyWithValue("alloc", BeNumerically("==", 100*mb))),
+		))
+		Expect(result).To(HaveKeyWithValue("after",
+			HaveKeyWithValue("runtime", HaveKeyWithValue("alloc", BeNumerically("==", 40*mb))),
+		))
+		Expect(result).To(HaveKeyWithValue("reclaimed", SatisfyAll(
+			HaveKeyWithValue("gc", "gc"),
+			HaveKeyWithValue("alloc", BeNumerically("==", 60*mb)),
+			HaveKeyWithValue("heapObjects", BeNumerically("==", 3000)),
+			HaveKeyWithValue("duration", BeAssignableToTypeOf("")),
+		)))
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(2))
+	})
+
+	It("frees OS memory", func() {
+		result := serve(http.MethodPost, "/debug/memory/gc?gc=free-os-memory")
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(result).To(HaveKeyWithValue("reclaimed", HaveKeyWithValue("gc", "free-os-memory")))
+	})
+
+	It("rejects an invalid gc", func() {
+		serve(http.MethodPost, "/debug/memory/gc?gc=off")
+		Expect(resp.Code).To(Equal(http.StatusBadRequest))
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(0))
+	})
+
+	It("rejects unauthorized GC", func() {
+		req := httptest.NewRequest(http.MethodPost, "/debug/memory/gc", nil)
+		handler.ServeHTTP(resp, req)
+		Expect(resp.Code).To(Equal(http.StatusUnauthorized))
+		Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(0))
+	})
+
+	It("returns an error if reading fails", func() {
+		containerMemoryReader.ReadContainerMemoryReturns(
+			log.ContainerMemory{},
+			errors.New("banana"),
+		)
+		serve(http.MethodGet, "/debug/memory")
+		Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+	})
+
+	It("rejects other methods", func() {
+		serve(http.MethodDelete, "/debug/memory")
+		Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+
+	It("responds to HEAD without body", func() {
+		handler.ServeHTTP(resp, httptest.NewRequest(http.MethodHead, "/debug/memory", nil))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Header().Get("Content-Type")).To(Equal("application/json"))
+		Expect(resp.Body.Len()).To(BeZero())
+	})
+
+	Context("admin routes", func() {
+		BeforeEach(func() {
+			serveMux := http.NewServeMux()
+			log.RegisterAdminRoutes(
+				serveMux,
+				"/debug",
+				log.NewAuthorizerBearerToken("secret"),
+				log.NewMemoryStatsAdminRoutes(
+					libtime.NewCurrentTime(),
+					memorySnapshotReader,
+					containerMemoryReader,
+					memoryMonitor,
+				),
+			)
+			handler = serveMux
+		})
+
+		It("runs the gc from the path", func() {
+			result := serve(http.MethodPost, "/debug/memory/gc/free-os-memory")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(result).To(HaveKeyWithValue(
+				"reclaimed",
+				HaveKeyWithValue("gc", "free-os-memory"),
+			))
+		})
+
+		It("returns the stats", func() {
+			serve(http.MethodGet, "/debug/memory")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+
+		It("rejects GET on the gc routes", func() {
+			serve(http.MethodGet, "/debug/memory/gc")
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(resp.Header().Get("Allow")).To(Equal("POST"))
+			Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(0))
+		})
+
+		It("rejects GET on the gc route with path", func() {
+			serve(http.MethodGet, "/debug/memory/gc/gc")
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(0))
+		})
+
+		It("rejects POST on the stats route", func() {
+			serve(http.MethodPost, "/debug/memory")
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(memorySnapshotReader.ReadMemorySnapshotCallCount()).To(Equal(0))
+		})
+
+		It("omits the container without ContainerMemoryReader", func() {
+			serveMux := http.NewServeMux()
+			log.RegisterAdminRoutes(
+				serveMux,
+				"/debug",
+				log.NewAuthorizerBearerToken("secret"),
+				log.NewMemoryStatsAdminRoutes(
+					libtime.NewCurrentTime(),
+					memorySnapshotReader,
+					nil,
+					nil,
+				),
+			)
+			handler = serveMux
+			result := serve(http.MethodPost, "/debug/memory/gc")
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(result).To(HaveKeyWithValue("before", Not(HaveKey("container"))))
+		})
+	})
+})
+
+var _ = Describe("Log MemoryMonitor checkpoints", func() {
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+
+	BeforeEach(func() {
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{Time: time.Now()})
+	})
+
+	It("keeps the newest checkpoints", func() {
+		memoryMonitor := log.NewMemoryMonitor(
+			0,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(nil),
+			log.WithMemoryCheckpointHistory(2),
+		)
+		memoryMonitor.LogMemoryUsage("first")
+		memoryMonitor.LogMemoryUsage("second")
+		memoryMonitor.LogMemoryUsage("third")
+		checkpoints := memoryMonitor.Checkpoints()
+		Expect(checkpoints).To(HaveLen(2))
+		Expect(checkpoints[0].Name).To(Equal("second"))
+		Expect(checkpoints[1].Name).To(Equal("third"))
+	})
+
+	It("disables the history", func() {
+		memoryMonitor := log.NewMemoryMonitor(
+			0,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithMemoryCheckpointHistory(0),
+		)
+		memoryMonitor.LogMemoryUsage("first")
+		Expect(memoryMonitor.Checkpoints()).To(BeEmpty())
+	})
+})
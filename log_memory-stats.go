@@ -5,22 +5,184 @@
 package log
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
 	"runtime"
+	"runtime/debug"
+	"time"
 
+	libtime "github.com/bborbe/time"
 	"github.com/golang/glog"
 )
 
-// MemoryStats is a helper that reads memory stats and logs them with the given prefix
-func MemoryStats(prefix string) {
+// MemorySnapshot holds the memory statistics of the process at one point in time.
+type MemorySnapshot struct {
+	// Time the snapshot was captured.
+	Time time.Time `json:"time"`
+	// Alloc is the number of bytes of allocated heap objects.
+	Alloc uint64 `json:"alloc"`
+	// TotalAlloc is the cumulative number of bytes allocated for heap objects.
+	TotalAlloc uint64 `json:"totalAlloc"`
+	// Sys is the number of bytes of memory obtained from the OS.
+	Sys uint64 `json:"sys"`
+	// HeapInuse is the number of bytes in in-use heap spans.
+	HeapInuse uint64 `json:"heapInuse"`
+	// HeapObjects is the number of allocated heap objects.
+	HeapObjects uint64 `json:"heapObjects"`
+	// Mallocs is the cumulative count of heap objects allocated.
+	Mallocs uint64 `json:"mallocs"`
+	// Frees is the cumulative count of heap objects freed.
+	Frees uint64 `json:"frees"`
+	// NumGC is the number of completed GC cycles.
+	NumGC uint32 `json:"numGC"`
+	// PauseTotal is the cumulative time of GC stop-the-world pauses.
+	PauseTotal time.Duration `json:"pauseTotal"`
+	// ScannableHeap is the number of bytes of heap the GC has to scan, zero if unknown.
+	ScannableHeap uint64 `json:"scannableHeap,omitempty"`
+	// GCCPUFraction is the fraction of CPU time used by the GC since the program started.
+	GCCPUFraction float64 `json:"gcCPUFraction"`
+	// Goroutines is the number of live goroutines.
+	Goroutines uint64 `json:"goroutines"`
+	// MemoryLimit is the Go runtime soft memory limit (GOMEMLIMIT), math.MaxInt64 if unset.
+	MemoryLimit uint64 `json:"memoryLimit,omitempty"`
+	// HeapLive is the number of heap bytes marked live by the last completed GC, which is
+	// the heap after GC, zero if unknown.
+	HeapLive uint64 `json:"heapLive,omitempty"`
+	// HeapReleased is the number of bytes of physical memory returned to the OS.
+	HeapReleased uint64 `json:"heapReleased"`
+}
+
+// CaptureMemorySnapshot reads the memory statistics of the process with runtime.ReadMemStats,
+// which stops the world. ScannableHeap and HeapLive are not available and always zero.
+// Use NewRuntimeMetricsReader for a low-overhead alternative.
+func CaptureMemorySnapshot() MemorySnapshot {
 	var memStats runtime.MemStats
 	runtime.ReadMemStats(&memStats)
-	glog.Infof(
-		"MEMORY USAGE - %s - Alloc: %.2f MB, Sys: %.2f MB, HeapInUse: %.2f MB, HeapObjects: %d, NumGC: %d",
-		prefix,
-		float64(memStats.Alloc)/1024/1024,
-		float64(memStats.Sys)/1024/1024,
-		float64(memStats.HeapInuse)/1024/1024,
-		memStats.HeapObjects,
-		memStats.NumGC,
+	return MemorySnapshot{
+		Time:          libtime.Now(),
+		Alloc:         memStats.Alloc,
+		TotalAlloc:    memStats.TotalAlloc,
+		Sys:           memStats.Sys,
+		HeapInuse:     memStats.HeapInuse,
+		HeapObjects:   memStats.HeapObjects,
+		Mallocs:       memStats.Mallocs,
+		Frees:         memStats.Frees,
+		NumGC:         memStats.NumGC,
+		PauseTotal:    time.Duration(memStats.PauseTotalNs),
+		GCCPUFraction: memStats.GCCPUFraction,
+		Goroutines:    uint64(runtime.NumGoroutine()),
+		HeapReleased:  memStats.HeapReleased,
+		// a negative limit only reads the current limit
+		MemoryLimit: uint64(debug.SetMemoryLimit(-1)),
+	}
+}
+
+// Retained returns the memory obtained from the OS and not returned to it (Sys minus
+// HeapReleased), which is the memory the runtime counts against GOMEMLIMIT.
+func (m MemorySnapshot) Retained() uint64 {
+	if m.HeapReleased > m.Sys {
+		return 0
+	}
+	return m.Sys - m.HeapReleased
+}
+
+// MarshalJSON encodes PauseTotal as Go duration string and adds the utilization of GOMEMLIMIT
+// as memoryLimitPercent. MemoryLimit is omitted if GOMEMLIMIT is not set.
+func (m MemorySnapshot) MarshalJSON() ([]byte, error) {
+	type memorySnapshot MemorySnapshot
+	value := struct {
+		memorySnapshot
+		PauseTotal         string  `json:"pauseTotal"`
+		MemoryLimit        uint64  `json:"memoryLimit,omitempty"`
+		MemoryLimitPercent float64 `json:"memoryLimitPercent,omitempty"`
+	}{
+		memorySnapshot: memorySnapshot(m),
+		PauseTotal:     m.PauseTotal.String(),
+	}
+	if m.MemoryLimit > 0 && m.MemoryLimit < math.MaxInt64 {
+		value.MemoryLimit = m.MemoryLimit
+		value.MemoryLimitPercent = percentOf(m.Retained(), m.MemoryLimit)
+	}
+	return json.Marshal(value)
+}
+
+// Diff returns the changes from previous to this snapshot.
+//
+// Example:
+//
+//	before := log.CaptureMemorySnapshot()
+//	process(batch)
+//	diff := log.CaptureMemorySnapshot().Diff(before)
+//	glog.V(2).Infof("batch allocated %d bytes in %d objects", diff.AllocatedBytes, diff.AllocatedObjects)
+func (m MemorySnapshot) Diff(previous MemorySnapshot) MemorySnapshotDiff {
+	return MemorySnapshotDiff{
+		Duration:         m.Time.Sub(previous.Time),
+		AllocatedBytes:   m.TotalAlloc - previous.TotalAlloc,
+		AllocatedObjects: m.Mallocs - previous.Mallocs,
+		FreedObjects:     m.Frees - previous.Frees,
+		NumGC:            m.NumGC - previous.NumGC,
+		PauseTotal:       m.PauseTotal - previous.PauseTotal,
+		Alloc:            int64(m.Alloc) - int64(previous.Alloc),
+		HeapInuse:        int64(m.HeapInuse) - int64(previous.HeapInuse),
+		HeapObjects:      int64(m.HeapObjects) - int64(previous.HeapObjects),
+	}
+}
+
+// String formats the snapshot like MemoryStats.
+func (m MemorySnapshot) String() string {
+	return fmt.Sprintf(
+		"Alloc: %.2f MB, Sys: %.2f MB, HeapInUse: %.2f MB, HeapObjects: %d, NumGC: %d",
+		toMB(m.Alloc),
+		toMB(m.Sys),
+		toMB(m.HeapInuse),
+		m.HeapObjects,
+		m.NumGC,
 	)
 }
+
+// MemorySnapshotDiff holds the changes between two MemorySnapshots.
+type MemorySnapshotDiff struct {
+	// Duration between the snapshots.
+	Duration time.Duration
+	// AllocatedBytes is the number of bytes allocated for heap objects in between.
+	AllocatedBytes uint64
+	// AllocatedObjects is the number of heap objects allocated in between.
+	AllocatedObjects uint64
+	// FreedObjects is the number of heap objects freed in between.
+	FreedObjects uint64
+	// NumGC is the number of GC cycles completed in between.
+	NumGC uint32
+	// PauseTotal is the GC pause time in between.
+	PauseTotal time.Duration
+	// Alloc is the change of allocated heap bytes, negative if the heap shrank.
+	Alloc int64
+	// HeapInuse is the change of in-use heap span bytes.
+	HeapInuse int64
+	// HeapObjects is the change of allocated heap objects.
+	HeapObjects int64
+}
+
+// String formats the diff for log lines.
+func (m MemorySnapshotDiff) String() string {
+	return fmt.Sprintf(
+		"Allocated: %.2f MB in %d objects, Alloc: %+.2f MB, HeapObjects: %+d, "+
+			"GC: %d (pause %v) in %v",
+		toMB(m.AllocatedBytes),
+		m.AllocatedObjects,
+		float64(m.Alloc)/1024/1024,
+		m.HeapObjects,
+		m.NumGC,
+		m.PauseTotal,
+		m.Duration.Round(time.Millisecond),
+	)
+}
+
+// MemoryStats is a helper that reads memory stats and logs them with the given prefix
+func MemoryStats(prefix string) {
+	glog.Infof("MEMORY USAGE - %s - %s", prefix, CaptureMemorySnapshot())
+}
+
+func toMB(value uint64) float64 {
+	return float64(value) / 1024 / 1024
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+)
+
+var _ = Describe("Log MemorySnapshot", func() {
+	var previous log.MemorySnapshot
+	var current log.MemorySnapshot
+
+	BeforeEach(func() {
+		now := time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		previous = log.MemorySnapshot{
+			Time:        now,
+			Alloc:       8 * 1024 * 1024,
+			TotalAlloc:  100 * 1024 * 1024,
+			Sys:         32 * 1024 * 1024,
+			HeapInuse:   10 * 1024 * 1024,
+			HeapObjects: 5000,
+			Mallocs:     20000,
+			Frees:       15000,
+			NumGC:       10,
+			PauseTotal:  5 * time.Millisecond,
+		}
+		current = log.MemorySnapshot{
+			Time:        now.Add(time.Minute),
+			Alloc:       6 * 1024 * 1024,
+			TotalAlloc:  150 * 1024 * 1024,
+			Sys:         32 * 1024 * 1024,
+			HeapInuse:   9 * 1024 * 1024,
+			HeapObjects: 4000,
+			Mallocs:     30000,
+			Frees:       26000,
+			NumGC:       13,
+			PauseTotal:  7 * time.Millisecond,
+		}
+	})
+
+	It("captures the current memory statistics", func() {
+		snapshot := log.CaptureMemorySnapshot()
+		Expect(snapshot.Time).NotTo(BeZero())
+		Expect(snapshot.Sys).To(BeNumerically(">", 0))
+		Expect(snapshot.TotalAlloc).To(BeNumerically(">=", snapshot.Alloc))
+		Expect(snapshot.Mallocs).To(BeNumerically(">=", snapshot.Frees))
+	})
+
+	It("returns the changes since the previous snapshot", func() {
+		Expect(current.Diff(previous)).To(Equal(log.MemorySnapshotDiff{
+			Duration:         time.Minute,
+			AllocatedBytes:   50 * 1024 * 1024,
+			AllocatedObjects: 10000,
+			FreedObjects:     11000,
+			NumGC:            3,
+			PauseTotal:       2 * time.Millisecond,
+			Alloc:            -2 * 1024 * 1024,
+			HeapInuse:        -1024 * 1024,
+			HeapObjects:      -1000,
+		}))
+	})
+
+	It("formats the snapshot", func() {
+		Expect(current.String()).To(Equal(
+			"Alloc: 6.00 MB, Sys: 32.00 MB, HeapInUse: 9.00 MB, HeapObjects: 4000, NumGC: 13",
+		))
+	})
+
+	It("formats the diff", func() {
+		Expect(current.Diff(previous).String()).To(Equal(
+			"Allocated: 50.00 MB in 10000 objects, Alloc: -2.00 MB, HeapObjects: -1000, " +
+				"GC: 3 (pause 2ms) in 1m0s",
+		))
+	})
+})
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"fmt"
+	"math"
+)
+
+// MemoryThresholds are checked by MemoryMonitor at every checkpoint.
+// A zero value disables the threshold.
+type MemoryThresholds struct {
+	// HeapBytes is the maximum of allocated heap bytes (Alloc).
+	HeapBytes uint64
+	// LimitPercent is the maximum of memory in use in percent of Limit. Check uses the memory
+	// retained from the OS (Sys minus HeapReleased), MemoryMonitor the RSS of its
+	// ContainerMemoryReader if known.
+	LimitPercent float64
+	// Limit is the memory limit LimitPercent refers to, e.g. the cgroup memory limit.
+	// Zero uses the Go runtime soft memory limit (GOMEMLIMIT) if set, MemoryMonitor falls
+	// back to the container memory limit of its ContainerMemoryReader.
+	Limit uint64
+	// GrowthBytesPerSecond is the maximum growth of allocated heap bytes between two
+	// checkpoints.
+	GrowthBytesPerSecond float64
+}
+
+// MemoryThresholdViolation describes a crossed threshold.
+type MemoryThresholdViolation struct {
+	// Name of the threshold: "heap", "limit" or "growth".
+	Name      string
+	Value     float64
+	Threshold float64
+}
+
+// String formats the violation for log lines.
+func (m MemoryThresholdViolation) String() string {
+	switch m.Name {
+	case "heap":
+		return fmt.Sprintf("heap %.2f MB exceeds %.2f MB", m.Value/1024/1024, m.Threshold/1024/1024)
+	case "limit":
+		return fmt.Sprintf("memory %.1f%% of limit exceeds %.1f%%", m.Value, m.Threshold)
+	case "growth":
+		return fmt.Sprintf(
+			"heap growth %.2f MB/s exceeds %.2f MB/s",
+			m.Value/1024/1024,
+			m.Threshold/1024/1024,
+		)
+	default:
+		return fmt.Sprintf("%s %.2f exceeds %.2f", m.Name, m.Value, m.Threshold)
+	}
+}
+
+// Check returns all thresholds crossed by current. The growth is calculated against previous,
+// a previous snapshot with zero time skips the growth threshold.
+func (m MemoryThresholds) Check(
+	current MemorySnapshot,
+	previous MemorySnapshot,
+) []MemoryThresholdViolation {
+	return m.check(current, previous, current.Retained())
+}
+
+// check is Check with the memory in use the limit percent is calculated from.
+func (m MemoryThresholds) check(
+	current MemorySnapshot,
+	previous MemorySnapshot,
+	usage uint64,
+) []MemoryThresholdViolation {
+	var result []MemoryThresholdViolation
+	if m.HeapBytes > 0 && current.Alloc > m.HeapBytes {
+		result = append(result, MemoryThresholdViolation{
+			Name:      "heap",
+			Value:     float64(current.Alloc),
+			Threshold: float64(m.HeapBytes),
+		})
+	}
+	if limit := m.limit(current); m.LimitPercent > 0 && limit > 0 {
+		percent := percentOf(usage, limit)
+		if percent > m.LimitPercent {
+			result = append(result, MemoryThresholdViolation{
+				Name:      "limit",
+				Value:     percent,
+				Threshold: m.LimitPercent,
+			})
+		}
+	}
+	if m.GrowthBytesPerSecond > 0 && !previous.Time.IsZero() {
+		diff := current.Diff(previous)
+		if seconds := diff.Duration.Seconds(); seconds > 0 {
+			growth := float64(diff.Alloc) / seconds
+			if growth > m.GrowthBytesPerSecond {
+				result = append(result, MemoryThresholdViolation{
+					Name:      "growth",
+					Value:     growth,
+					Threshold: m.GrowthBytesPerSecond,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// limit returns Limit or the memory limit of the snapshot, zero if none is set.
+func (m MemoryThresholds) limit(snapshot MemorySnapshot) uint64 {
+	if m.Limit > 0 {
+		return m.Limit
+	}
+	if snapshot.MemoryLimit == 0 || snapshot.MemoryLimit >= math.MaxInt64 {
+		// GOMEMLIMIT not set
+		return 0
+	}
+	return snapshot.MemoryLimit
+}
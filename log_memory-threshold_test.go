@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+const mb = 1024 * 1024
+
+var _ = Describe("Log MemoryThresholds", func() {
+	var now time.Time
+	var previous log.MemorySnapshot
+	var current log.MemorySnapshot
+
+	BeforeEach(func() {
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		previous = log.MemorySnapshot{
+			Time:        now,
+			Alloc:       100 * mb,
+			Sys:         200 * mb,
+			MemoryLimit: math.MaxInt64,
+		}
+		current = log.MemorySnapshot{
+			Time:        now.Add(10 * time.Second),
+			Alloc:       150 * mb,
+			Sys:         300 * mb,
+			MemoryLimit: math.MaxInt64,
+		}
+	})
+
+	It("returns nothing without thresholds", func() {
+		Expect(log.MemoryThresholds{}.Check(current, previous)).To(BeEmpty())
+	})
+
+	It("checks the absolute heap", func() {
+		thresholds := log.MemoryThresholds{HeapBytes: 120 * mb}
+		Expect(thresholds.Check(current, previous)).To(Equal([]log.MemoryThresholdViolation{
+			{Name: "heap", Value: 150 * mb, Threshold: 120 * mb},
+		}))
+		Expect(thresholds.Check(previous, log.MemorySnapshot{})).To(BeEmpty())
+	})
+
+	It("checks percent of the configured limit", func() {
+		thresholds := log.MemoryThresholds{LimitPercent: 50, Limit: 400 * mb}
+		Expect(thresholds.Check(current, previous)).To(Equal([]log.MemoryThresholdViolation{
+			{Name: "limit", Value: 75, Threshold: 50},
+		}))
+	})
+
+	It("checks percent of GOMEMLIMIT", func() {
+		current.MemoryLimit = 1000 * mb
+		thresholds := log.MemoryThresholds{LimitPercent: 25}
+		Expect(thresholds.Check(current, previous)).To(Equal([]log.MemoryThresholdViolation{
+			{Name: "limit", Value: 30, Threshold: 25},
+		}))
+	})
+
+	It("excludes memory released to the OS from the limit percent", func() {
+		current.HeapReleased = 100 * mb
+		thresholds := log.MemoryThresholds{LimitPercent: 60, Limit: 400 * mb}
+		Expect(thresholds.Check(current, previous)).To(BeEmpty())
+		Expect(current.Retained()).To(Equal(uint64(200 * mb)))
+	})
+
+	It("ignores the limit percent without limit", func() {
+		thresholds := log.MemoryThresholds{LimitPercent: 1}
+		Expect(thresholds.Check(current, previous)).To(BeEmpty())
+	})
+
+	It("checks the growth rate", func() {
+		thresholds := log.MemoryThresholds{GrowthBytesPerSecond: 1 * mb}
+		Expect(thresholds.Check(current, previous)).To(Equal([]log.MemoryThresholdViolation{
+			{Name: "growth", Value: 5 * mb, Threshold: 1 * mb},
+		}))
+		Expect(thresholds.Check(current, log.MemorySnapshot{})).To(BeEmpty())
+	})
+
+	It("formats violations", func() {
+		Expect(log.MemoryThresholdViolation{Name: "heap", Value: 150 * mb, Threshold: 120 * mb}.
+			String()).To(Equal("heap 150.00 MB exceeds 120.00 MB"))
+		Expect(log.MemoryThresholdViolation{Name: "limit", Value: 75, Threshold: 50}.String()).
+			To(Equal("memory 75.0% of limit exceeds 50.0%"))
+		Expect(log.MemoryThresholdViolation{Name: "growth", Value: 5 * mb, Threshold: mb}.String()).
+			To(Equal("heap growth 5.00 MB/s exceeds 1.00 MB/s"))
+	})
+})
+
+var _ = Describe("Log MemoryProfileWriter", func() {
+	var ctx context.Context
+	var now time.Time
+	var currentTime libtime.CurrentTime
+	var dir string
+	var memoryProfileWriter log.MemoryProfileWriter
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		currentTime = libtime.NewCurrentTime()
+		currentTime.SetNow(now)
+		dir = filepath.Join(GinkgoT().TempDir(), "profiles")
+		memoryProfileWriter = log.NewMemoryProfileWriter(
+			currentTime,
+			dir,
+			time.Minute,
+			2,
+		)
+	})
+
+	files := func() []string {
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return names
+	}
+
+	It("writes heap profile and goroutine dump", func() {
+		paths, err := memoryProfileWriter.WriteProfiles(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(Equal([]string{
+			filepath.Join(dir, "heap-20261018T020000.000Z.pb.gz"),
+			filepath.Join(dir, "goroutine-20261018T020000.000Z.txt"),
+		}))
+		content, err := os.ReadFile(paths[1])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("goroutine"))
+	})
+
+	It("rate limits writes", func() {
+		_, err := memoryProfileWriter.WriteProfiles(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		now = now.Add(30 * time.Second)
+		currentTime.SetNow(now)
+		paths, err := memoryProfileWriter.WriteProfiles(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paths).To(BeEmpty())
+		Expect(files()).To(HaveLen(2))
+	})
+
+	It("keeps the newest files", func() {
+		for i := 0; i < 3; i++ {
+			_, err := memoryProfileWriter.WriteProfiles(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			now = now.Add(time.Minute)
+			currentTime.SetNow(now)
+		}
+		Expect(files()).To(ConsistOf(
+			"goroutine-20261018T020100.000Z.txt",
+			"goroutine-20261018T020200.000Z.txt",
+			"heap-20261018T020100.000Z.pb.gz",
+			"heap-20261018T020200.000Z.pb.gz",
+		))
+	})
+})
+
+var _ = Describe("Log MemoryMonitor thresholds", func() {
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+	var memoryProfileWriter *mocks.MemoryProfileWriter
+	var memoryMonitor log.MemoryMonitor
+
+	BeforeEach(func() {
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memoryProfileWriter = &mocks.MemoryProfileWriter{}
+		memoryMonitor = log.NewMemoryMonitor(
+			0,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithMemoryThresholds(log.MemoryThresholds{HeapBytes: 100 * mb}),
+			log.WithMemoryProfileWriter(memoryProfileWriter),
+		)
+	})
+
+	It("writes profiles when a threshold is crossed", func() {
+		memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{
+			Time:  time.Now(),
+			Alloc: 50 * mb,
+		})
+		memoryMonitor.LogMemoryUsage("below")
+		Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(0))
+
+		memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{
+			Time:  time.Now(),
+			Alloc: 150 * mb,
+		})
+		memoryMonitor.LogMemoryUsage("above")
+		Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(1))
+	})
+
+	It("continues if writing profiles fails", func() {
+		memoryProfileWriter.WriteProfilesReturns(nil, errors.New("banana"))
+		memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{
+			Time:  time.Now(),
+			Alloc: 150 * mb,
+		})
+		memoryMonitor.LogMemoryUsage("above")
+		memoryMonitor.LogMemoryUsage("still above")
+		Expect(memoryProfileWriter.WriteProfilesCallCount()).To(Equal(2))
+	})
+})
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+//counterfeiter:generate -o mocks/log-memory-trend-analyzer.go --fake-name MemoryTrendAnalyzer . MemoryTrendAnalyzer
+
+// MemoryTrendAnalyzer detects slow memory leaks by fitting the growth of the heap after GC
+// over a rolling window of samples.
+type MemoryTrendAnalyzer interface {
+	// AddSample adds the heap after GC of snapshot to the window. Snapshots without a
+	// completed GC since the previous sample are ignored, they would repeat the same value.
+	AddSample(snapshot MemorySnapshot)
+	// Trend returns the growth fitted over the window and the estimated time until limit
+	// is reached. A zero limit skips the estimation.
+	Trend(limit uint64) MemoryTrend
+}
+
+// MemoryTrend is the heap growth fitted by a MemoryTrendAnalyzer.
+type MemoryTrend struct {
+	// Samples is the number of samples in the window.
+	Samples int
+	// Duration between the first and the last sample.
+	Duration time.Duration
+	// Heap is the heap after GC of the last sample.
+	Heap uint64
+	// BytesPerSecond is the fitted growth of the heap, negative if it shrinks.
+	BytesPerSecond float64
+	// Limit the estimation refers to, zero if none.
+	Limit uint64
+	// TimeToLimit is the estimated time until Limit is reached, zero if the heap does not
+	// grow, no limit is known or it is already reached. It is capped at the maximum
+	// time.Duration for very slow growth.
+	TimeToLimit time.Duration
+	// Sustained is true if the window holds enough samples and the growth exceeds the
+	// threshold of the analyzer.
+	Sustained bool
+}
+
+// String formats the trend for log lines.
+func (m MemoryTrend) String() string {
+	result := fmt.Sprintf(
+		"heap %.2f MB growing %.2f MB/h over %d samples in %v",
+		toMB(m.Heap),
+		m.BytesPerSecond*3600/1024/1024,
+		m.Samples,
+		m.Duration.Round(time.Second),
+	)
+	switch {
+	case m.Limit == 0:
+		return result + ", no limit"
+	case m.Heap >= m.Limit:
+		return result + fmt.Sprintf(", limit %.2f MB reached", toMB(m.Limit))
+	case m.TimeToLimit > 0:
+		return result + fmt.Sprintf(
+			", limit %.2f MB reached in ~%v",
+			toMB(m.Limit),
+			m.TimeToLimit.Round(time.Minute),
+		)
+	default:
+		return result + fmt.Sprintf(", limit %.2f MB", toMB(m.Limit))
+	}
+}
+
+// memoryTrendSample is the heap after GC at one point in time.
+type memoryTrendSample struct {
+	time  time.Time
+	heap  uint64
+	numGC uint32
+}
+
+// NewMemoryTrendAnalyzer creates a MemoryTrendAnalyzer that keeps the last windowSize
+// samples. The trend is sustained once at least minSamples samples grow faster than
+// growthBytesPerSecond, fitted with a linear least squares regression.
+// HeapLive of the snapshot is used as heap after GC. Snapshots without HeapLive, e.g. of
+// NewMemStatsReader, are dropped, so a series never mixes different metrics.
+//
+// Example:
+//
+//	memoryMonitor := log.NewMemoryMonitor(
+//	    time.Minute,
+//	    log.WithMemoryTrendAnalyzer(log.NewMemoryTrendAnalyzer(60, 10, 1024)),
+//	)
+func NewMemoryTrendAnalyzer(
+	windowSize int,
+	minSamples int,
+	growthBytesPerSecond float64,
+) MemoryTrendAnalyzer {
+	return &memoryTrendAnalyzer{
+		windowSize:           max(windowSize, 2),
+		minSamples:           max(minSamples, 2),
+		growthBytesPerSecond: growthBytesPerSecond,
+	}
+}
+
+type memoryTrendAnalyzer struct {
+	windowSize           int
+	minSamples           int
+	growthBytesPerSecond float64
+
+	mux     sync.Mutex
+	samples []memoryTrendSample
+}
+
+func (m *memoryTrendAnalyzer) AddSample(snapshot MemorySnapshot) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if snapshot.HeapLive == 0 {
+		return
+	}
+	if len(m.samples) > 0 && snapshot.NumGC <= m.samples[len(m.samples)-1].numGC {
+		return
+	}
+	m.samples = append(m.samples, memoryTrendSample{
+		time:  snapshot.Time,
+		heap:  snapshot.HeapLive,
+		numGC: snapshot.NumGC,
+	})
+	if len(m.samples) > m.windowSize {
+		m.samples = m.samples[len(m.samples)-m.windowSize:]
+	}
+}
+
+func (m *memoryTrendAnalyzer) Trend(limit uint64) MemoryTrend {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	trend := MemoryTrend{
+		Samples: len(m.samples),
+		Limit:   limit,
+	}
+	if len(m.samples) == 0 {
+		return trend
+	}
+	first, last := m.samples[0], m.samples[len(m.samples)-1]
+	trend.Duration = last.time.Sub(first.time)
+	trend.Heap = last.heap
+	trend.BytesPerSecond = m.slope()
+	trend.Sustained = trend.Samples >= m.minSamples &&
+		trend.BytesPerSecond > m.growthBytesPerSecond
+	if trend.BytesPerSecond > 0 && limit > trend.Heap {
+		seconds := float64(limit-trend.Heap) / trend.BytesPerSecond
+		nanoseconds := seconds * float64(time.Second)
+		// cap instead of overflowing, math.MaxInt64 is 2^63 as float64
+		trend.TimeToLimit = time.Duration(math.MaxInt64)
+		if nanoseconds < math.MaxInt64 {
+			trend.TimeToLimit = time.Duration(nanoseconds)
+		}
+	}
+	return trend
+}
+
+// slope fits the heap over the seconds since the first sample with least squares.
+// The caller must hold the mutex.
+func (m *memoryTrendAnalyzer) slope() float64 {
+	n := float64(len(m.samples))
+	first := m.samples[0].time
+	var sumX, sumY float64
+	for _, sample := range m.samples {
+		sumX += sample.time.Sub(first).Seconds()
+		sumY += float64(sample.heap)
+	}
+	meanX, meanY := sumX/n, sumY/n
+	var covariance, variance float64
+	for _, sample := range m.samples {
+		x := sample.time.Sub(first).Seconds() - meanX
+		covariance += x * (float64(sample.heap) - meanY)
+		variance += x * x
+	}
+	if variance == 0 {
+		return 0
+	}
+	return covariance / variance
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"math"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log MemoryTrendAnalyzer", func() {
+	var now time.Time
+	var numGC uint32
+	var memoryTrendAnalyzer log.MemoryTrendAnalyzer
+
+	BeforeEach(func() {
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		numGC = 0
+		memoryTrendAnalyzer = log.NewMemoryTrendAnalyzer(4, 3, 1024)
+	})
+
+	add := func(heap uint64) {
+		numGC++
+		memoryTrendAnalyzer.AddSample(log.MemorySnapshot{
+			Time:     now,
+			Alloc:    heap + 50*mb,
+			HeapLive: heap,
+			NumGC:    numGC,
+		})
+		now = now.Add(time.Minute)
+	}
+
+	It("returns an empty trend without samples", func() {
+		Expect(memoryTrendAnalyzer.Trend(0)).To(Equal(log.MemoryTrend{}))
+	})
+
+	It("fits the growth of the heap after GC", func() {
+		add(100 * mb)
+		add(106 * mb)
+		add(112 * mb)
+		trend := memoryTrendAnalyzer.Trend(472 * mb)
+		Expect(trend.Samples).To(Equal(3))
+		Expect(trend.Duration).To(Equal(2 * time.Minute))
+		Expect(trend.Heap).To(Equal(uint64(112 * mb)))
+		Expect(trend.BytesPerSecond).To(BeNumerically("~", 0.1*mb, 1))
+		Expect(trend.Sustained).To(BeTrue())
+		Expect(trend.TimeToLimit).To(BeNumerically("~", time.Hour, time.Second))
+		Expect(trend.String()).To(Equal(
+			"heap 112.00 MB growing 360.00 MB/h over 3 samples in 2m0s, " +
+				"limit 472.00 MB reached in ~1h0m0s",
+		))
+	})
+
+	It("caps the time to limit for very slow growth", func() {
+		add(100 * mb)
+		add(100*mb + 6)
+		add(100*mb + 12)
+		trend := memoryTrendAnalyzer.Trend(64 * 1024 * mb)
+		Expect(trend.BytesPerSecond).To(BeNumerically("~", 0.1, 0.001))
+		Expect(trend.TimeToLimit).To(Equal(time.Duration(math.MaxInt64)))
+	})
+
+	It("is not sustained with too few samples", func() {
+		add(100 * mb)
+		add(200 * mb)
+		trend := memoryTrendAnalyzer.Trend(0)
+		Expect(trend.BytesPerSecond).To(BeNumerically(">", 1024))
+		Expect(trend.Sustained).To(BeFalse())
+		Expect(trend.TimeToLimit).To(BeZero())
+		Expect(trend.String()).To(HaveSuffix(", no limit"))
+	})
+
+	It("is not sustained below the threshold", func() {
+		add(100 * mb)
+		add(100 * mb)
+		add(100*mb + 1024)
+		trend := memoryTrendAnalyzer.Trend(200 * mb)
+		Expect(trend.Sustained).To(BeFalse())
+	})
+
+	It("ignores snapshots without GC", func() {
+		add(100 * mb)
+		memoryTrendAnalyzer.AddSample(log.MemorySnapshot{
+			Time:     now,
+			HeapLive: 500 * mb,
+			NumGC:    numGC,
+		})
+		Expect(memoryTrendAnalyzer.Trend(0).Samples).To(Equal(1))
+	})
+
+	It("drops samples without HeapLive", func() {
+		add(100 * mb)
+		memoryTrendAnalyzer.AddSample(log.MemorySnapshot{
+			Time:  now.Add(time.Hour),
+			Alloc: 42 * mb,
+			NumGC: numGC + 1,
+		})
+		trend := memoryTrendAnalyzer.Trend(0)
+		Expect(trend.Samples).To(Equal(1))
+		Expect(trend.Heap).To(Equal(uint64(100 * mb)))
+	})
+
+	It("keeps a rolling window", func() {
+		add(100 * mb)
+		add(200 * mb)
+		for i := 0; i < 4; i++ {
+			add(300 * mb)
+		}
+		trend := memoryTrendAnalyzer.Trend(400 * mb)
+		Expect(trend.Samples).To(Equal(4))
+		Expect(trend.BytesPerSecond).To(BeZero())
+		Expect(trend.Sustained).To(BeFalse())
+		Expect(trend.TimeToLimit).To(BeZero())
+	})
+})
+
+var _ = Describe("Log MemoryMonitor trend", func() {
+	var memorySnapshotReader *mocks.MemorySnapshotReader
+	var containerMemoryReader *mocks.ContainerMemoryReader
+	var memoryTrendAnalyzer *mocks.MemoryTrendAnalyzer
+	var memoryMonitor log.MemoryMonitor
+
+	BeforeEach(func() {
+		memorySnapshotReader = &mocks.MemorySnapshotReader{}
+		memorySnapshotReader.ReadMemorySnapshotReturns(log.MemorySnapshot{
+			Time:        time.Now(),
+			HeapLive:    100 * mb,
+			MemoryLimit: math.MaxInt64,
+		})
+		containerMemoryReader = &mocks.ContainerMemoryReader{}
+		containerMemoryReader.ReadContainerMemoryReturns(log.ContainerMemory{
+			CgroupVersion: 2,
+			Limit:         500 * mb,
+		}, nil)
+		memoryTrendAnalyzer = &mocks.MemoryTrendAnalyzer{}
+		memoryTrendAnalyzer.TrendReturns(log.MemoryTrend{Sustained: true})
+		memoryMonitor = log.NewMemoryMonitor(
+			0,
+			log.WithMemorySnapshotReader(memorySnapshotReader),
+			log.WithContainerMemoryReader(containerMemoryReader),
+			log.WithMemoryTrendAnalyzer(memoryTrendAnalyzer),
+		)
+	})
+
+	It("adds every checkpoint and estimates against the container limit", func() {
+		memoryMonitor.LogMemoryUsage("first")
+		memoryMonitor.LogMemoryUsage("second")
+		Expect(memoryTrendAnalyzer.AddSampleCallCount()).To(Equal(2))
+		Expect(memoryTrendAnalyzer.AddSampleArgsForCall(0).HeapLive).To(Equal(uint64(100 * mb)))
+		Expect(memoryTrendAnalyzer.TrendCallCount()).To(Equal(2))
+		Expect(memoryTrendAnalyzer.TrendArgsForCall(1)).To(Equal(uint64(500 * mb)))
+	})
+})
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// namedLoggerLevelJSON is the JSON representation of a NamedLoggerLevel.
+type namedLoggerLevelJSON struct {
+	Name       string     `json:"name"`
+	Level      glog.Level `json:"level"`
+	Overridden bool       `json:"overridden"`
+	ResetAt    *time.Time `json:"resetAt,omitempty"`
+}
+
+func newNamedLoggerLevelJSONs(levels []NamedLoggerLevel) []namedLoggerLevelJSON {
+	result := make([]namedLoggerLevelJSON, 0, len(levels))
+	for _, level := range levels {
+		entry := namedLoggerLevelJSON{
+			Name:       level.Name,
+			Level:      level.Level,
+			Overridden: level.Overridden,
+		}
+		if !level.ResetAt.IsZero() {
+			resetAt := level.ResetAt
+			entry.ResetAt = &resetAt
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// NewNamedLoggerHandler creates an HTTP handler to manage the levels of a NamedLoggerRegistry:
+//
+//	GET    - list the levels of all loggers as JSON
+//	POST   - set the level of logger "name" until the auto-reset of the registry
+//	DELETE - reset logger "name" to inherit the level of its parent
+//
+// The name is read from the path variable "name" or the query parameter "name", a missing
+// name addresses the root. The level is parsed with NewLogLevelParser.
+// POST and DELETE respond with the levels of all loggers.
+//
+// Example requests:
+//
+//	POST   /debug/loggers/kafka.consumer/4
+//	POST   /debug/loggers/kafka?level=3
+//	DELETE /debug/loggers/kafka
+func NewNamedLoggerHandler(namedLoggerRegistry NamedLoggerRegistry) http.Handler {
+	logLevelParser := NewLogLevelParser()
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		switch req.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPost, http.MethodPut:
+			level, err := logLevelParser.ParseLogLevel(req)
+			if err != nil {
+				http.Error(resp, err.Error(), http.StatusBadRequest)
+				return
+			}
+			name := requestVar(req, "name")
+			if err := namedLoggerRegistry.SetLevel(ctx, name, level); err != nil {
+				http.Error(resp, err.Error(), http.StatusBadRequest)
+				return
+			}
+		case http.MethodDelete:
+			if err := namedLoggerRegistry.ResetLevel(ctx, requestVar(req, "name")); err != nil {
+				http.Error(resp, err.Error(), http.StatusBadRequest)
+				return
+			}
+		default:
+			resp.Header().Set("Allow", "GET, HEAD, POST, PUT, DELETE")
+			http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(
+			resp,
+			req,
+			http.StatusOK,
+			newNamedLoggerLevelJSONs(namedLoggerRegistry.Levels(ctx)),
+		)
+	})
+}
+
+// NewNamedLoggerAdminRoutes returns the routes to manage the levels of named loggers:
+//
+//	GET    {prefix}/loggers                 - list levels
+//	POST   {prefix}/loggers/{name}/{level}  - set level of logger
+//	POST   {prefix}/loggers/{name}?level=.. - set level of logger
+//	DELETE {prefix}/loggers/{name}          - reset level of logger
+func NewNamedLoggerAdminRoutes(namedLoggerRegistry NamedLoggerRegistry) AdminRoutes {
+	handler := NewNamedLoggerHandler(namedLoggerRegistry)
+	return AdminRoutes{
+		{Pattern: "/loggers/{name}/{level}", Handler: handler},
+		{Pattern: "/loggers/{name}", Handler: handler},
+		{Pattern: "/loggers", Handler: handler},
+	}
+}
@@ -0,0 +1,302 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+//counterfeiter:generate -o mocks/log-named-logger.go --fake-name NamedLogger . NamedLogger
+
+// NamedLogger is a logger for a component with a dotted hierarchical name like
+// "kafka.consumer". It embeds the Sampler created for it by the SamplerFactory of its
+// NamedLoggerRegistry.
+type NamedLogger interface {
+	Sampler
+	// Name returns the dotted name of the logger.
+	Name() string
+	// Level returns the effective level, inherited from the closest parent with a level.
+	Level() glog.Level
+	// V reports whether verbosity at the given level is enabled either for the component
+	// or globally (-v, -vmodule).
+	V(level glog.Level) glog.Verbose
+}
+
+// NamedLoggerLevel describes the level of a name in a NamedLoggerRegistry.
+type NamedLoggerLevel struct {
+	Name string
+	// Level is the effective level of the name.
+	Level glog.Level
+	// Overridden is true if the level is set for this name and not inherited.
+	Overridden bool
+	// ResetAt is the time the override is removed, zero if not overridden.
+	ResetAt time.Time
+}
+
+//counterfeiter:generate -o mocks/log-named-logger-registry.go --fake-name NamedLoggerRegistry . NamedLoggerRegistry
+
+// NamedLoggerRegistry creates named loggers and manages their levels at runtime.
+// A level set for a name applies to the name and all children without an own level,
+// e.g. a level for "kafka" applies to "kafka.consumer" and "kafka.producer".
+// The empty name is the root, its level applies to all loggers.
+type NamedLoggerRegistry interface {
+	// Logger returns the logger with the given name, the same instance for the same name.
+	Logger(name string) NamedLogger
+	// SetLevel sets the level of name and its children and removes it after the
+	// auto-reset duration of the registry. Names without a logger yet are allowed.
+	SetLevel(ctx context.Context, name string, level glog.Level) error
+	// ResetLevel removes the level of name, so it inherits the level of its parent again.
+	ResetLevel(ctx context.Context, name string) error
+	// Levels returns the levels of all loggers and overridden names sorted by name.
+	Levels(ctx context.Context) []NamedLoggerLevel
+}
+
+// NewNamedLoggerRegistry creates a NamedLoggerRegistry. Every logger gets its own sampler
+// from samplerFactory. The root level is defaultLogLevel, levels set with SetLevel are
+// removed after autoResetDuration.
+//
+// Example:
+//
+//	registry := log.NewNamedLoggerRegistry(
+//	    log.DefaultSamplerFactory,
+//	    libtime.NewCurrentTime(),
+//	    glog.Level(0),
+//	    5*time.Minute,
+//	)
+//	logger := registry.Logger("kafka.consumer")
+//	logger.V(3).Infof("received message %s", key)
+//	if logger.IsSample() {
+//	    glog.Warningf("consumer lag %d", lag)
+//	}
+//
+//	// inspect all kafka components for the next five minutes
+//	err := registry.SetLevel(ctx, "kafka", 4)
+func NewNamedLoggerRegistry(
+	samplerFactory SamplerFactory,
+	currentTimeGetter libtime.CurrentTimeGetter,
+	defaultLogLevel glog.Level,
+	autoResetDuration time.Duration,
+) NamedLoggerRegistry {
+	return &namedLoggerRegistry{
+		samplerFactory:    samplerFactory,
+		currentTimeGetter: currentTimeGetter,
+		defaultLogLevel:   defaultLogLevel,
+		autoResetDuration: autoResetDuration,
+		loggers:           make(map[string]*namedLogger),
+		overrides:         make(map[string]*namedLoggerOverride),
+	}
+}
+
+type namedLoggerRegistry struct {
+	samplerFactory    SamplerFactory
+	currentTimeGetter libtime.CurrentTimeGetter
+	defaultLogLevel   glog.Level
+	autoResetDuration time.Duration
+
+	mux       sync.Mutex
+	loggers   map[string]*namedLogger
+	overrides map[string]*namedLoggerOverride
+	// generation invalidates resets scheduled by earlier calls of SetLevel
+	generation uint64
+}
+
+type namedLoggerOverride struct {
+	level      glog.Level
+	resetAt    time.Time
+	timer      *deadlineTimer
+	generation uint64
+}
+
+func (n *namedLoggerRegistry) Logger(name string) NamedLogger {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	logger, ok := n.loggers[name]
+	if !ok {
+		logger = &namedLogger{
+			Sampler: n.samplerFactory.Sampler(),
+			name:    name,
+		}
+		logger.level.Store(int32(n.levelLocked(name)))
+		n.loggers[name] = logger
+	}
+	return logger
+}
+
+func (n *namedLoggerRegistry) SetLevel(ctx context.Context, name string, level glog.Level) error {
+	if err := validateLoggerName(ctx, name); err != nil {
+		return errors.Wrapf(ctx, err, "set level of logger '%s' failed", name)
+	}
+
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	if override, ok := n.overrides[name]; ok && override.timer != nil {
+		override.timer.Stop()
+	}
+	n.generation++
+	generation := n.generation
+	resetAt := n.currentTimeGetter.Now().Add(n.autoResetDuration)
+	n.overrides[name] = &namedLoggerOverride{
+		level:      level,
+		resetAt:    resetAt,
+		generation: generation,
+		timer: newDeadlineTimer(n.currentTimeGetter, resetAt, func() {
+			n.reset(name, generation)
+		}),
+	}
+	n.updateLocked()
+	glog.V(2).Infof(
+		"set level of logger '%s' to %d and reset in %v",
+		name,
+		level,
+		n.autoResetDuration,
+	)
+	return nil
+}
+
+func (n *namedLoggerRegistry) ResetLevel(ctx context.Context, name string) error {
+	if err := validateLoggerName(ctx, name); err != nil {
+		return errors.Wrapf(ctx, err, "reset level of logger '%s' failed", name)
+	}
+
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	n.removeLocked(name)
+	return nil
+}
+
+func (n *namedLoggerRegistry) Levels(ctx context.Context) []NamedLoggerLevel {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	names := make(map[string]struct{}, len(n.loggers)+len(n.overrides))
+	for name := range n.loggers {
+		names[name] = struct{}{}
+	}
+	for name := range n.overrides {
+		names[name] = struct{}{}
+	}
+	result := make([]NamedLoggerLevel, 0, len(names))
+	for name := range names {
+		entry := NamedLoggerLevel{
+			Name:  name,
+			Level: n.levelLocked(name),
+		}
+		if override, ok := n.overrides[name]; ok {
+			entry.Overridden = true
+			entry.ResetAt = override.resetAt
+		}
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// reset is called by the timer of an override.
+func (n *namedLoggerRegistry) reset(name string, generation uint64) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	override, ok := n.overrides[name]
+	if !ok || override.generation != generation {
+		// set again or reset in the meantime
+		return
+	}
+	n.removeLocked(name)
+}
+
+// removeLocked removes the override of name and updates all loggers.
+// The caller must hold the mutex.
+func (n *namedLoggerRegistry) removeLocked(name string) {
+	override, ok := n.overrides[name]
+	if !ok {
+		return
+	}
+	if override.timer != nil {
+		override.timer.Stop()
+	}
+	delete(n.overrides, name)
+	n.updateLocked()
+	glog.V(2).Infof("level of logger '%s' reset", name)
+}
+
+// updateLocked recalculates the effective level of all loggers.
+// The caller must hold the mutex.
+func (n *namedLoggerRegistry) updateLocked() {
+	for name, logger := range n.loggers {
+		logger.level.Store(int32(n.levelLocked(name)))
+	}
+}
+
+// levelLocked returns the level of the closest override of name or its parents.
+// The caller must hold the mutex.
+func (n *namedLoggerRegistry) levelLocked(name string) glog.Level {
+	for {
+		if override, ok := n.overrides[name]; ok {
+			return override.level
+		}
+		if name == "" {
+			return n.defaultLogLevel
+		}
+		name = parentLoggerName(name)
+	}
+}
+
+// parentLoggerName returns the name of the parent, the root "" for top level names.
+func parentLoggerName(name string) string {
+	pos := strings.LastIndexByte(name, '.')
+	if pos < 0 {
+		return ""
+	}
+	return name[:pos]
+}
+
+// validateLoggerName allows the root "" and dotted names without empty segments.
+func validateLoggerName(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+	for _, segment := range strings.Split(name, ".") {
+		if strings.TrimSpace(segment) != segment || segment == "" {
+			return errors.Errorf(ctx, "invalid logger name '%s'", name)
+		}
+	}
+	return nil
+}
+
+type namedLogger struct {
+	Sampler
+	name string
+	// level is the effective level, updated by the registry on every change
+	level atomic.Int32
+}
+
+func (n *namedLogger) Name() string {
+	return n.name
+}
+
+func (n *namedLogger) Level() glog.Level {
+	return glog.Level(n.level.Load())
+}
+
+func (n *namedLogger) V(level glog.Level) glog.Verbose {
+	if level <= n.Level() {
+		return glog.Verbose(true)
+	}
+	return glog.VDepth(1, level)
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log NamedLoggerRegistry", func() {
+	var ctx context.Context
+	var now time.Time
+	var currentTime libtime.CurrentTime
+	var samplerFactory *mocks.LogSamplerFactory
+	var registry log.NamedLoggerRegistry
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		currentTime = libtime.NewCurrentTime()
+		currentTime.SetNow(now)
+		samplerFactory = &mocks.LogSamplerFactory{}
+		samplerFactory.SamplerReturns(log.NewSamplerTrue())
+		registry = log.NewNamedLoggerRegistry(
+			samplerFactory,
+			currentTime,
+			glog.Level(1),
+			time.Hour,
+		)
+	})
+
+	It("returns the same logger for the same name", func() {
+		logger := registry.Logger("kafka.consumer")
+		Expect(logger.Name()).To(Equal("kafka.consumer"))
+		Expect(registry.Logger("kafka.consumer")).To(BeIdenticalTo(logger))
+		Expect(samplerFactory.SamplerCallCount()).To(Equal(1))
+	})
+
+	It("creates a sampler per logger", func() {
+		registry.Logger("kafka.consumer")
+		registry.Logger("kafka.producer")
+		Expect(samplerFactory.SamplerCallCount()).To(Equal(2))
+		Expect(registry.Logger("kafka.consumer").IsSample()).To(BeTrue())
+	})
+
+	It("uses the default level", func() {
+		Expect(registry.Logger("kafka.consumer").Level()).To(Equal(glog.Level(1)))
+	})
+
+	It("inherits the level of the closest parent", func() {
+		consumer := registry.Logger("kafka.consumer")
+		producer := registry.Logger("kafka.producer")
+		pool := registry.Logger("db.pool")
+		Expect(registry.SetLevel(ctx, "kafka", 3)).To(Succeed())
+		Expect(registry.SetLevel(ctx, "kafka.consumer", 5)).To(Succeed())
+		Expect(consumer.Level()).To(Equal(glog.Level(5)))
+		Expect(producer.Level()).To(Equal(glog.Level(3)))
+		Expect(pool.Level()).To(Equal(glog.Level(1)))
+	})
+
+	It("applies the root level to all loggers", func() {
+		Expect(registry.SetLevel(ctx, "", 2)).To(Succeed())
+		Expect(registry.Logger("db.pool").Level()).To(Equal(glog.Level(2)))
+	})
+
+	It("applies levels set before the logger is created", func() {
+		Expect(registry.SetLevel(ctx, "kafka", 3)).To(Succeed())
+		Expect(registry.Logger("kafka.consumer").Level()).To(Equal(glog.Level(3)))
+	})
+
+	It("enables V up to the effective level", func() {
+		logger := registry.Logger("kafka.consumer")
+		Expect(registry.SetLevel(ctx, "kafka", 4)).To(Succeed())
+		Expect(bool(logger.V(4))).To(BeTrue())
+		Expect(bool(logger.V(100))).To(BeFalse())
+	})
+
+	It("inherits again after reset", func() {
+		logger := registry.Logger("kafka.consumer")
+		Expect(registry.SetLevel(ctx, "kafka.consumer", 5)).To(Succeed())
+		Expect(registry.ResetLevel(ctx, "kafka.consumer")).To(Succeed())
+		Expect(logger.Level()).To(Equal(glog.Level(1)))
+	})
+
+	It("rejects invalid names", func() {
+		Expect(registry.SetLevel(ctx, "kafka..consumer", 3)).NotTo(Succeed())
+		Expect(registry.SetLevel(ctx, ".kafka", 3)).NotTo(Succeed())
+		Expect(registry.ResetLevel(ctx, "kafka.")).NotTo(Succeed())
+	})
+
+	It("lists loggers and overrides", func() {
+		registry.Logger("kafka.consumer")
+		Expect(registry.SetLevel(ctx, "kafka", 3)).To(Succeed())
+		Expect(registry.Levels(ctx)).To(Equal([]log.NamedLoggerLevel{
+			{Name: "kafka", Level: 3, Overridden: true, ResetAt: now.Add(time.Hour)},
+			{Name: "kafka.consumer", Level: 3},
+		}))
+	})
+
+	Context("auto-reset", func() {
+		BeforeEach(func() {
+			registry = log.NewNamedLoggerRegistry(
+				samplerFactory,
+				libtime.NewCurrentTime(),
+				glog.Level(1),
+				100*time.Millisecond,
+			)
+		})
+
+		It("removes the level after the auto-reset duration", func() {
+			logger := registry.Logger("kafka.consumer")
+			Expect(registry.SetLevel(ctx, "kafka", 3)).To(Succeed())
+			Expect(logger.Level()).To(Equal(glog.Level(3)))
+			Eventually(logger.Level).Should(Equal(glog.Level(1)))
+			Expect(registry.Levels(ctx)).To(HaveLen(1))
+		})
+
+		It("restarts the auto-reset when set again", func() {
+			logger := registry.Logger("kafka.consumer")
+			Expect(registry.SetLevel(ctx, "kafka", 3)).To(Succeed())
+			time.Sleep(60 * time.Millisecond)
+			Expect(registry.SetLevel(ctx, "kafka", 4)).To(Succeed())
+			Consistently(logger.Level, 60*time.Millisecond).Should(Equal(glog.Level(4)))
+			Eventually(logger.Level).Should(Equal(glog.Level(1)))
+		})
+	})
+})
+
+var _ = Describe("Log NamedLoggerHandler", func() {
+	var registry *mocks.NamedLoggerRegistry
+	var serveMux *http.ServeMux
+	var recorder *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		registry = &mocks.NamedLoggerRegistry{}
+		registry.LevelsReturns([]log.NamedLoggerLevel{
+			{
+				Name:       "kafka",
+				Level:      3,
+				Overridden: true,
+				ResetAt:    time.Date(2026, 10, 18, 2, 5, 0, 0, time.UTC),
+			},
+			{Name: "kafka.consumer", Level: 3},
+		})
+		serveMux = http.NewServeMux()
+		log.RegisterAdminRoutes(
+			serveMux,
+			"/debug",
+			log.NewAuthorizerAllowAll(),
+			log.NewNamedLoggerAdminRoutes(registry),
+		)
+		recorder = httptest.NewRecorder()
+	})
+
+	serve := func(method string, target string) {
+		serveMux.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	}
+
+	It("lists levels", func() {
+		serve(http.MethodGet, "/debug/loggers")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(`[
+			{"name":"kafka","level":3,"overridden":true,"resetAt":"2026-10-18T02:05:00Z"},
+			{"name":"kafka.consumer","level":3,"overridden":false}
+		]`))
+	})
+
+	It("sets level from path", func() {
+		serve(http.MethodPost, "/debug/loggers/kafka.consumer/4")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(registry.SetLevelCallCount()).To(Equal(1))
+		_, name, level := registry.SetLevelArgsForCall(0)
+		Expect(name).To(Equal("kafka.consumer"))
+		Expect(level).To(Equal(glog.Level(4)))
+	})
+
+	It("sets level from query", func() {
+		serve(http.MethodPost, "/debug/loggers/kafka?level=3")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(registry.SetLevelCallCount()).To(Equal(1))
+		_, name, level := registry.SetLevelArgsForCall(0)
+		Expect(name).To(Equal("kafka"))
+		Expect(level).To(Equal(glog.Level(3)))
+	})
+
+	It("rejects missing level", func() {
+		serve(http.MethodPost, "/debug/loggers/kafka")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(registry.SetLevelCallCount()).To(Equal(0))
+	})
+
+	It("returns registry errors", func() {
+		registry.SetLevelReturns(errors.New("banana"))
+		serve(http.MethodPost, "/debug/loggers/kafka/3")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("resets level", func() {
+		serve(http.MethodDelete, "/debug/loggers/kafka")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(registry.ResetLevelCallCount()).To(Equal(1))
+		_, name := registry.ResetLevelArgsForCall(0)
+		Expect(name).To(Equal("kafka"))
+	})
+
+	It("rejects other methods", func() {
+		serve(http.MethodPatch, "/debug/loggers/kafka")
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
@@ -0,0 +1,85 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/golang/glog"
+)
+
+// LogLevelHeader is the request header read by NewRequestLogLevelMiddleware.
+const LogLevelHeader = "X-Log-Level"
+
+type logLevelContextKey struct{}
+
+// WithLogLevel returns a copy of ctx that carries a request-scoped log level.
+// V returns true for all levels up to logLevel when called with the returned context.
+func WithLogLevel(ctx context.Context, logLevel glog.Level) context.Context {
+	return context.WithValue(ctx, logLevelContextKey{}, logLevel)
+}
+
+// LogLevelFromContext returns the request-scoped log level stored by WithLogLevel.
+func LogLevelFromContext(ctx context.Context) (glog.Level, bool) {
+	logLevel, ok := ctx.Value(logLevelContextKey{}).(glog.Level)
+	return logLevel, ok
+}
+
+// V is a context-aware replacement for glog.V. It reports whether verbosity at the given
+// level is enabled either globally (-v, -vmodule) or for the request the context belongs to.
+//
+// Example:
+//
+//	log.V(ctx, 4).Infof("payload: %s", payload)
+//
+// The global log level set by a LogLevelSetter is not changed.
+func V(ctx context.Context, level glog.Level) glog.Verbose {
+	if logLevel, ok := LogLevelFromContext(ctx); ok && level <= logLevel {
+		return glog.Verbose(true)
+	}
+	return glog.VDepth(1, level)
+}
+
+// NewRequestLogLevelMiddleware creates a middleware that enables verbose logging for a
+// single request. If a request carries the header "X-Log-Level: <level>" and the authorizer
+// allows it, the level is stored in the request context (see WithLogLevel and V).
+//
+// Levels above maxLogLevel are lowered to maxLogLevel. Unauthorized requests and invalid
+// header values are served normally without elevated level, they are never rejected.
+//
+// Example:
+//
+//	handler = log.NewRequestLogLevelMiddleware(log.NewAuthorizerBearerToken(token), 5, handler)
+//
+//	// inside the handler
+//	log.V(req.Context(), 4).Infof("request body: %s", body)
+func NewRequestLogLevelMiddleware(
+	authorizer Authorizer,
+	maxLogLevel glog.Level,
+	handler http.Handler,
+) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		value := req.Header.Get(LogLevelHeader)
+		if value == "" {
+			handler.ServeHTTP(resp, req)
+			return
+		}
+		if err := authorizer.Authorize(req); err != nil {
+			glog.V(2).Infof("ignore header %s of unauthorized request: %v", LogLevelHeader, err)
+			handler.ServeHTTP(resp, req)
+			return
+		}
+		level, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			glog.V(2).Infof("ignore invalid header %s: %v", LogLevelHeader, err)
+			handler.ServeHTTP(resp, req)
+			return
+		}
+		logLevel := min(glog.Level(int32(level)), maxLogLevel)
+		handler.ServeHTTP(resp, req.WithContext(WithLogLevel(req.Context(), logLevel)))
+	})
+}
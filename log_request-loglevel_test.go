@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+)
+
+var _ = Describe("Log RequestLogLevel", Serial, func() {
+	var ctx context.Context
+	var originalLevel string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		originalLevel = flag.Lookup("v").Value.String()
+		Expect(flag.Set("v", "1")).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(flag.Set("v", originalLevel)).To(Succeed())
+	})
+
+	Context("V", func() {
+		It("uses global level without request level", func() {
+			Expect(bool(log.V(ctx, 1))).To(BeTrue())
+			Expect(bool(log.V(ctx, 2))).To(BeFalse())
+		})
+		It("uses request level", func() {
+			ctx = log.WithLogLevel(ctx, 4)
+			Expect(bool(log.V(ctx, 4))).To(BeTrue())
+			Expect(bool(log.V(ctx, 5))).To(BeFalse())
+		})
+		It("does not change global level", func() {
+			_ = log.V(log.WithLogLevel(ctx, 4), 4)
+			Expect(bool(glog.V(2))).To(BeFalse())
+		})
+	})
+
+	Context("LogLevelFromContext", func() {
+		It("returns false without level", func() {
+			_, ok := log.LogLevelFromContext(ctx)
+			Expect(ok).To(BeFalse())
+		})
+		It("returns stored level", func() {
+			level, ok := log.LogLevelFromContext(log.WithLogLevel(ctx, 3))
+			Expect(ok).To(BeTrue())
+			Expect(level).To(Equal(glog.Level(3)))
+		})
+	})
+
+	Context("NewRequestLogLevelMiddleware", func() {
+		var handler http.Handler
+		var verbose bool
+		var found bool
+
+		BeforeEach(func() {
+			verbose = false
+			found = false
+			handler = log.NewRequestLogLevelMiddleware(
+				log.NewAuthorizerBearerToken("secret"),
+				glog.Level(5),
+				http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+					verbose = bool(log.V(req.Context(), 4))
+					_, found = log.LogLevelFromContext(req.Context())
+				}),
+			)
+		})
+
+		serve := func(header string, token string) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if header != "" {
+				req.Header.Set(log.LogLevelHeader, header)
+			}
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		It("elevates level for authorized request", func() {
+			serve("4", "secret")
+			Expect(verbose).To(BeTrue())
+		})
+		It("caps level at max", func() {
+			var level glog.Level
+			handler = log.NewRequestLogLevelMiddleware(
+				log.NewAuthorizerAllowAll(),
+				glog.Level(2),
+				http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+					level, _ = log.LogLevelFromContext(req.Context())
+				}),
+			)
+			serve("9", "")
+			Expect(level).To(Equal(glog.Level(2)))
+		})
+		It("ignores header of unauthorized request", func() {
+			serve("4", "wrong")
+			Expect(verbose).To(BeFalse())
+			Expect(found).To(BeFalse())
+		})
+		It("ignores invalid header", func() {
+			serve("banana", "secret")
+			Expect(verbose).To(BeFalse())
+			Expect(found).To(BeFalse())
+		})
+		It("serves request without header", func() {
+			serve("", "")
+			Expect(verbose).To(BeFalse())
+			Expect(found).To(BeFalse())
+		})
+		It("does not change global level", func() {
+			serve("4", "secret")
+			Expect(bool(glog.V(2))).To(BeFalse())
+		})
+	})
+})
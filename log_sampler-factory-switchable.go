@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+//counterfeiter:generate -o mocks/log-switchable-sampler-factory.go --fake-name SwitchableSamplerFactory . SwitchableSamplerFactory
+
+// SwitchableSamplerFactory is a SamplerFactory that can be reconfigured at runtime.
+// Samplers created before a switch follow it: with their next IsSample call they are
+// replaced by a new sampler of the current SamplerFactory.
+type SwitchableSamplerFactory interface {
+	SamplerFactory
+	// SetSamplerFactory replaces the SamplerFactory used for all samplers.
+	SetSamplerFactory(samplerFactory SamplerFactory)
+	// CurrentSamplerFactory returns the SamplerFactory set last.
+	CurrentSamplerFactory() SamplerFactory
+}
+
+// NewSwitchableSamplerFactory creates a SwitchableSamplerFactory starting with samplerFactory.
+// It is safe for concurrent use.
+func NewSwitchableSamplerFactory(samplerFactory SamplerFactory) SwitchableSamplerFactory {
+	result := &switchableSamplerFactory{}
+	result.SetSamplerFactory(samplerFactory)
+	return result
+}
+
+type switchableSamplerFactory struct {
+	// current changes its pointer on every switch, samplers use it to detect switches
+	current atomic.Pointer[samplerFactoryHolder]
+}
+
+type samplerFactoryHolder struct {
+	samplerFactory SamplerFactory
+}
+
+func (s *switchableSamplerFactory) Sampler() Sampler {
+	return &switchableSampler{
+		switchableSamplerFactory: s,
+	}
+}
+
+func (s *switchableSamplerFactory) SetSamplerFactory(samplerFactory SamplerFactory) {
+	s.current.Store(&samplerFactoryHolder{samplerFactory: samplerFactory})
+}
+
+func (s *switchableSamplerFactory) CurrentSamplerFactory() SamplerFactory {
+	return s.current.Load().samplerFactory
+}
+
+type switchableSampler struct {
+	switchableSamplerFactory *switchableSamplerFactory
+
+	mux     sync.Mutex
+	holder  *samplerFactoryHolder
+	sampler Sampler
+}
+
+func (s *switchableSampler) IsSample() bool {
+	holder := s.switchableSamplerFactory.current.Load()
+
+	s.mux.Lock()
+	if s.holder != holder {
+		s.holder = holder
+		s.sampler = holder.samplerFactory.Sampler()
+	}
+	sampler := s.sampler
+	s.mux.Unlock()
+
+	return sampler.IsSample()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+)
+
+var _ = Describe("Log SwitchableSamplerFactory", func() {
+	var falseSamplerFactory log.SamplerFactory
+	var trueSamplerFactory log.SamplerFactory
+	var switchableSamplerFactory log.SwitchableSamplerFactory
+
+	BeforeEach(func() {
+		falseSamplerFactory = log.SamplerFactoryFunc(func() log.Sampler {
+			return log.SamplerFunc(func() bool { return false })
+		})
+		trueSamplerFactory = log.SamplerFactoryFunc(log.NewSamplerTrue)
+		switchableSamplerFactory = log.NewSwitchableSamplerFactory(falseSamplerFactory)
+	})
+
+	It("uses the initial sampler factory", func() {
+		Expect(switchableSamplerFactory.Sampler().IsSample()).To(BeFalse())
+	})
+
+	It("switches existing samplers", func() {
+		sampler := switchableSamplerFactory.Sampler()
+		Expect(sampler.IsSample()).To(BeFalse())
+		switchableSamplerFactory.SetSamplerFactory(trueSamplerFactory)
+		Expect(sampler.IsSample()).To(BeTrue())
+		Expect(switchableSamplerFactory.CurrentSamplerFactory()).NotTo(BeNil())
+	})
+
+	It("keeps the state of a sampler until the next switch", func() {
+		switchableSamplerFactory.SetSamplerFactory(log.SamplerFactoryFunc(func() log.Sampler {
+			return log.NewSampleMod(2)
+		}))
+		sampler := switchableSamplerFactory.Sampler()
+		results := []bool{sampler.IsSample(), sampler.IsSample(), sampler.IsSample()}
+		Expect(results).To(ContainElement(BeTrue()))
+		Expect(results).To(ContainElement(BeFalse()))
+	})
+
+	It("is safe for concurrent use", func() {
+		sampler := switchableSamplerFactory.Sampler()
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				sampler.IsSample()
+			}()
+			go func() {
+				defer wg.Done()
+				switchableSamplerFactory.SetSamplerFactory(trueSamplerFactory)
+			}()
+		}
+		wg.Wait()
+		Expect(sampler.IsSample()).To(BeTrue())
+	})
+})
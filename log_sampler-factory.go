@@ -8,12 +8,23 @@ import "time"
 
 // DefaultSamplerFactory provides a default sampler configuration that combines
 // time-based sampling (every 10 seconds) with glog level sampling (level 4+).
-var DefaultSamplerFactory SamplerFactory = SamplerFactoryFunc(func() Sampler {
+// It is switchable: samplers created by it follow reconfigurations at runtime,
+// e.g. by a LoggingProfileManager created with it.
+//
+// Example:
+//
+//	sampler := log.DefaultSamplerFactory.Sampler()
+//	// sample every log message of all samplers created by DefaultSamplerFactory
+//	log.DefaultSamplerFactory.SetSamplerFactory(log.SamplerFactoryFunc(log.NewSamplerTrue))
+var DefaultSamplerFactory = NewSwitchableSamplerFactory(SamplerFactoryFunc(newDefaultSampler))
+
+// newDefaultSampler creates the sampler of the initial DefaultSamplerFactory configuration.
+func newDefaultSampler() Sampler {
 	return SamplerList{
 		NewSampleTime(10 * time.Second),
 		NewSamplerGlogLevel(4),
 	}
-})
+}
 
 //counterfeiter:generate -o mocks/log-sampler-factory.go --fake-name LogSamplerFactory . SamplerFactory
 
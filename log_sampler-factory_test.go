@@ -20,6 +20,26 @@ var _ = Describe("Log SamplerFactory", func() {
 		It("returns a sampler", func() {
 			Expect(samplerFactory.Sampler()).NotTo(BeNil())
 		})
+		Context("SetSamplerFactory", Serial, func() {
+			var initial log.SamplerFactory
+			BeforeEach(func() {
+				initial = log.DefaultSamplerFactory.CurrentSamplerFactory()
+			})
+			AfterEach(func() {
+				log.DefaultSamplerFactory.SetSamplerFactory(initial)
+			})
+			It("switches samplers created before", func() {
+				sampler := samplerFactory.Sampler()
+				Expect(sampler.IsSample()).To(BeTrue())
+				Expect(sampler.IsSample()).To(BeFalse())
+
+				log.DefaultSamplerFactory.SetSamplerFactory(
+					log.SamplerFactoryFunc(log.NewSamplerTrue),
+				)
+				Expect(sampler.IsSample()).To(BeTrue())
+				Expect(sampler.IsSample()).To(BeTrue())
+			})
+		})
 	})
 	Context("SamplerFactoryFunc", func() {
 		var sampler log.Sampler
@@ -25,7 +25,8 @@ import (
 //	}
 //
 // Parameters:
-//   - duration: The minimum time interval between samples. Must be > 0.
+//   - duration: The minimum time interval between samples. The first call is always sampled,
+//     a non-positive duration samples every call.
 //
 // The sampler is thread-safe and can be used concurrently from multiple goroutines.
 // It uses github.com/bborbe/time for consistent time handling across the library.
@@ -35,10 +36,11 @@ func NewSampleTime(duration stdtime.Duration) Sampler {
 	return SamplerFunc(func() bool {
 		mux.Lock()
 		defer mux.Unlock()
-		if libtime.Now().Sub(lastlog) <= duration {
+		now := libtime.Now()
+		if duration > 0 && !lastlog.IsZero() && now.Sub(lastlog) <= duration {
 			return false
 		}
-		lastlog = libtime.Now()
+		lastlog = now
 		return true
 	})
 }
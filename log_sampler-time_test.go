@@ -5,6 +5,7 @@
 package log_test
 
 import (
+	"math"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -39,6 +40,15 @@ var _ = Describe("Log SamplerTime", func() {
 				Expect(sampler.IsSample()).To(BeFalse())
 			})
 		})
+		Context("with max duration", func() {
+			BeforeEach(func() {
+				sampler = log.NewSampleTime(math.MaxInt64)
+			})
+			It("returns true only on first call", func() {
+				Expect(sampler.IsSample()).To(BeTrue())
+				Expect(sampler.IsSample()).To(BeFalse())
+			})
+		})
 		Context("with zero duration", func() {
 			BeforeEach(func() {
 				sampler = log.NewSampleTime(0)
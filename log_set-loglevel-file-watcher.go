@@ -0,0 +1,298 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+// LogLevelFileConfig is the content of a file watched by NewLogLevelFileWatcher.
+// Fields not present in the file are nil.
+type LogLevelFileConfig struct {
+	// V is the glog verbosity, applied through the LogLevelManager.
+	V *glog.Level
+	// Vmodule is the glog per-module verbosity, e.g. "kafka=3,db*=2".
+	Vmodule *string
+	// Expiry is the absolute time after which the config is no longer applied.
+	Expiry *time.Time
+}
+
+// ParseLogLevelFileConfig parses the content of a log level file.
+// Each line contains "key=value" or "key: value", empty lines and lines starting
+// with "#" are ignored. Supported keys are:
+//
+//	v=4
+//	vmodule=kafka=3,db*=2
+//	expiry=2026-10-18T02:30:00Z
+//
+// Returns an error for unknown keys or invalid values.
+func ParseLogLevelFileConfig(ctx context.Context, content []byte) (*LogLevelFileConfig, error) {
+	var config LogLevelFileConfig
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pos := strings.IndexAny(line, "=:")
+		if pos < 0 {
+			return nil, errors.Errorf(ctx, "invalid line '%s'", line)
+		}
+		key := strings.TrimSpace(line[:pos])
+		value := strings.TrimSpace(line[pos+1:])
+		if err := config.set(ctx, key, value); err != nil {
+			return nil, errors.Wrapf(ctx, err, "parse line '%s' failed", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(ctx, err, "scan content failed")
+	}
+	return &config, nil
+}
+
+func (c *LogLevelFileConfig) set(ctx context.Context, key string, value string) error {
+	switch key {
+	case "v":
+		level, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "parse v failed")
+		}
+		logLevel := glog.Level(int32(level))
+		c.V = &logLevel
+	case "vmodule":
+		c.Vmodule = &value
+	case "expiry":
+		expiry, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return errors.Wrapf(ctx, err, "parse expiry failed")
+		}
+		c.Expiry = &expiry
+	default:
+		return errors.Errorf(ctx, "unknown key '%s'", key)
+	}
+	return nil
+}
+
+// Expired returns true if the config has an expiry before now.
+func (c *LogLevelFileConfig) Expired(now time.Time) bool {
+	return c.Expiry != nil && now.After(*c.Expiry)
+}
+
+// LogLevelFileWatcher applies log level changes from a file.
+type LogLevelFileWatcher interface {
+	// Run polls the file until the context is cancelled.
+	Run(ctx context.Context) error
+}
+
+// LogLevelFileWatcherOption configures optional features of NewLogLevelFileWatcher.
+type LogLevelFileWatcherOption func(*logLevelFileWatcher)
+
+// WithLogLevelFileWatcherFlagSet changes the FlagSet holding the flag "vmodule",
+// e.g. the FlagSet passed to WithLogLevelFlagSet. The default is flag.CommandLine.
+func WithLogLevelFileWatcherFlagSet(flagSet *flag.FlagSet) LogLevelFileWatcherOption {
+	return func(l *logLevelFileWatcher) {
+		l.flagSet = flagSet
+	}
+}
+
+// WithLogLevelFileWatcherCurrentTimeGetter changes the clock the expiry of the file is
+// compared with, e.g. the clock passed to WithLogLevelCurrentTimeGetter.
+// The default is libtime.NewCurrentTime.
+func WithLogLevelFileWatcherCurrentTimeGetter(
+	currentTimeGetter libtime.CurrentTimeGetter,
+) LogLevelFileWatcherOption {
+	return func(l *logLevelFileWatcher) {
+		l.currentTimeGetter = currentTimeGetter
+	}
+}
+
+// NewLogLevelFileWatcher creates a LogLevelFileWatcher that polls the file at path every
+// pollInterval and applies changes of its content (see ParseLogLevelFileConfig).
+//
+// It is designed for Kubernetes ConfigMaps mounted as volume. The file is re-read on every
+// poll and compared by content, so the atomic symlink swap used by the kubelet is handled.
+// "v" is applied through the LogLevelManager until the expiry of the file. Without expiry
+// the auto-reset of NewLogLevelSetter applies and the level is applied again after it,
+// so the file stays in effect while it exists. "vmodule" is set on the flag directly.
+// Both are reset once the config expires, the key is removed or the file is deleted.
+//
+// Missing files and invalid content are logged and ignored, the watcher never stops
+// because of them.
+//
+// Example:
+//
+//	logLevelSetter := log.NewLogLevelSetter(glog.Level(1), 30*time.Minute)
+//	watcher := log.NewLogLevelFileWatcher(logLevelSetter, "/etc/loglevel/config", 10*time.Second)
+//	go watcher.Run(ctx)
+func NewLogLevelFileWatcher(
+	logLevelManager LogLevelManager,
+	path string,
+	pollInterval time.Duration,
+	options ...LogLevelFileWatcherOption,
+) LogLevelFileWatcher {
+	l := &logLevelFileWatcher{
+		logLevelManager:   logLevelManager,
+		flagSet:           flag.CommandLine,
+		currentTimeGetter: libtime.NewCurrentTime(),
+		path:              path,
+		pollInterval:      pollInterval,
+	}
+	for _, option := range options {
+		option(l)
+	}
+	return l
+}
+
+type logLevelFileWatcher struct {
+	logLevelManager   LogLevelManager
+	flagSet           *flag.FlagSet
+	currentTimeGetter libtime.CurrentTimeGetter
+	path              string
+	pollInterval      time.Duration
+
+	lastContent    []byte
+	config         *LogLevelFileConfig
+	initialVmodule string
+	vApplied       bool
+	vmoduleApplied bool
+}
+
+func (l *logLevelFileWatcher) Run(ctx context.Context) error {
+	if f := l.flagSet.Lookup("vmodule"); f != nil {
+		l.initialVmodule = f.Value.String()
+	}
+	defer l.reset(context.Background())
+
+	ticker := time.NewTicker(l.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		l.poll(ctx)
+		select {
+		case <-ctx.Done():
+			glog.V(2).Infof("log level file watcher for %s stopped", l.path)
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *logLevelFileWatcher) poll(ctx context.Context) {
+	if l.config != nil && l.config.Expired(l.currentTimeGetter.Now()) {
+		glog.V(2).Infof("log level file %s expired at %v", l.path, *l.config.Expiry)
+		l.config = nil
+		l.reset(ctx)
+	}
+
+	content, err := os.ReadFile(l.path)
+	if err != nil {
+		if !os.IsNotExist(err) || l.lastContent != nil {
+			glog.Warningf("read log level file %s failed: %v", l.path, err)
+		}
+		if os.IsNotExist(err) {
+			l.config = nil
+			l.reset(ctx)
+		}
+		l.lastContent = nil
+		return
+	}
+	if l.lastContent != nil && bytes.Equal(content, l.lastContent) {
+		if l.config != nil && l.config.V != nil && l.logLevelManager.State().AutoResetIn == 0 {
+			// the auto-reset of the LogLevelManager happened, but the file is still valid
+			l.applyV(ctx, *l.config.V, l.config.Expiry)
+		}
+		return
+	}
+	l.lastContent = content
+
+	config, err := ParseLogLevelFileConfig(ctx, content)
+	if err != nil {
+		glog.Warningf("ignore invalid log level file %s: %v", l.path, err)
+		return
+	}
+	l.apply(ctx, config)
+}
+
+func (l *logLevelFileWatcher) apply(ctx context.Context, config *LogLevelFileConfig) {
+	if config.Expired(l.currentTimeGetter.Now()) {
+		glog.V(2).Infof("ignore log level file %s expired at %v", l.path, *config.Expiry)
+		l.config = nil
+		l.reset(ctx)
+		return
+	}
+	l.config = config
+	if config.V == nil {
+		l.resetV(ctx)
+	} else {
+		l.applyV(ctx, *config.V, config.Expiry)
+	}
+	if config.Vmodule == nil {
+		l.restoreVmodule()
+		return
+	}
+	if err := l.flagSet.Set("vmodule", *config.Vmodule); err != nil {
+		glog.Warningf("set vmodule from file %s failed: %v", l.path, err)
+		return
+	}
+	l.vmoduleApplied = true
+	glog.V(2).Infof("set vmodule to '%s' from file %s", *config.Vmodule, l.path)
+}
+
+// applyV sets the log level until expiry or for the auto-reset duration without expiry.
+func (l *logLevelFileWatcher) applyV(ctx context.Context, logLevel glog.Level, expiry *time.Time) {
+	ctx = WithLogLevelChangeSource(ctx, LogLevelChangeSourceFile)
+	var err error
+	if expiry != nil {
+		err = l.logLevelManager.SetFor(ctx, logLevel, expiry.Sub(l.currentTimeGetter.Now()))
+	} else {
+		err = l.logLevelManager.Set(ctx, logLevel)
+	}
+	if err != nil {
+		glog.Warningf("set loglevel from file %s failed: %v", l.path, err)
+		return
+	}
+	l.vApplied = true
+}
+
+// reset sets v and vmodule back, if they were applied from the file.
+func (l *logLevelFileWatcher) reset(ctx context.Context) {
+	l.resetV(ctx)
+	l.restoreVmodule()
+}
+
+func (l *logLevelFileWatcher) resetV(ctx context.Context) {
+	if !l.vApplied {
+		return
+	}
+	ctx = WithLogLevelChangeSource(ctx, LogLevelChangeSourceFile)
+	if err := l.logLevelManager.Reset(ctx); err != nil {
+		glog.Warningf("reset loglevel from file %s failed: %v", l.path, err)
+		return
+	}
+	l.vApplied = false
+}
+
+func (l *logLevelFileWatcher) restoreVmodule() {
+	if !l.vmoduleApplied {
+		return
+	}
+	if err := l.flagSet.Set("vmodule", l.initialVmodule); err != nil {
+		glog.Warningf("restore vmodule to '%s' failed: %v", l.initialVmodule, err)
+		return
+	}
+	l.vmoduleApplied = false
+	glog.V(2).Infof("vmodule set back to '%s'", l.initialVmodule)
+}
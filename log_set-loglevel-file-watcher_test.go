@@ -0,0 +1,269 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log LogLevelFileWatcher", Serial, func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	Context("ParseLogLevelFileConfig", func() {
+		It("parses all keys", func() {
+			config, err := log.ParseLogLevelFileConfig(ctx, []byte(`
+# debug kafka
+v=4
+vmodule: kafka=3,db*=2
+expiry=2026-10-18T02:30:00Z
+`))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.V).ToNot(BeNil())
+			Expect(*config.V).To(Equal(glog.Level(4)))
+			Expect(config.Vmodule).ToNot(BeNil())
+			Expect(*config.Vmodule).To(Equal("kafka=3,db*=2"))
+			Expect(config.Expiry).ToNot(BeNil())
+			Expect(*config.Expiry).To(Equal(time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC)))
+		})
+		It("returns empty config for empty content", func() {
+			config, err := log.ParseLogLevelFileConfig(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.V).To(BeNil())
+			Expect(config.Vmodule).To(BeNil())
+			Expect(config.Expiry).To(BeNil())
+		})
+		It("returns error for unknown key", func() {
+			_, err := log.ParseLogLevelFileConfig(ctx, []byte("banana=1"))
+			Expect(err).To(HaveOccurred())
+		})
+		It("returns error for invalid level", func() {
+			_, err := log.ParseLogLevelFileConfig(ctx, []byte("v=banana"))
+			Expect(err).To(HaveOccurred())
+		})
+		It("returns error for invalid expiry", func() {
+			_, err := log.ParseLogLevelFileConfig(ctx, []byte("expiry=tomorrow"))
+			Expect(err).To(HaveOccurred())
+		})
+		It("returns error for line without separator", func() {
+			_, err := log.ParseLogLevelFileConfig(ctx, []byte("v 4"))
+			Expect(err).To(HaveOccurred())
+		})
+		It("detects expiry", func() {
+			config, err := log.ParseLogLevelFileConfig(ctx, []byte("expiry=2026-10-18T02:30:00Z"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Expired(time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC))).To(BeFalse())
+			Expect(config.Expired(time.Date(2026, 10, 18, 3, 0, 0, 0, time.UTC))).To(BeTrue())
+		})
+	})
+
+	Context("NewLogLevelFileWatcher", func() {
+		var cancel context.CancelFunc
+		var mockLogLevelManager *mocks.LogLevelManager
+		var flagSet *flag.FlagSet
+		var dir string
+		var path string
+		var done chan error
+
+		BeforeEach(func() {
+			ctx, cancel = context.WithCancel(ctx)
+			mockLogLevelManager = &mocks.LogLevelManager{}
+			mockLogLevelManager.StateReturns(log.LogLevelState{AutoResetIn: time.Hour})
+			flagSet = flag.NewFlagSet("test", flag.ContinueOnError)
+			flagSet.Var(&syncStringValue{value: "initial=1"}, "vmodule", "")
+			dir = GinkgoT().TempDir()
+			path = filepath.Join(dir, "loglevel")
+			done = make(chan error, 1)
+		})
+
+		AfterEach(func() {
+			cancel()
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		run := func() {
+			watcher := log.NewLogLevelFileWatcher(
+				mockLogLevelManager,
+				path,
+				10*time.Millisecond,
+				log.WithLogLevelFileWatcherFlagSet(flagSet),
+			)
+			go func() {
+				done <- watcher.Run(ctx)
+			}()
+		}
+
+		vmodule := func() string {
+			return flagSet.Lookup("vmodule").Value.String()
+		}
+
+		// swap writes the content the way the kubelet updates ConfigMap volumes:
+		// into a new timestamped directory and an atomic rename of the "..data" symlink.
+		swap := func(name string, content string) {
+			Expect(os.Mkdir(filepath.Join(dir, name), 0750)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, name, "loglevel"), []byte(content), 0600)).
+				To(Succeed())
+			Expect(os.Symlink(name, filepath.Join(dir, "..data_tmp"))).To(Succeed())
+			Expect(os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data"))).
+				To(Succeed())
+		}
+
+		It("applies level from file", func() {
+			Expect(os.WriteFile(path, []byte("v=3\n"), 0600)).To(Succeed())
+			run()
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(1))
+			actualCtx, level := mockLogLevelManager.SetArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(3)))
+			Expect(log.LogLevelChangeSourceFromContext(actualCtx)).
+				To(Equal(log.LogLevelChangeSourceFile))
+		})
+
+		It("applies level until the expiry of the file", func() {
+			expiry := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+			Expect(os.WriteFile(path, []byte("v=3\nexpiry="+expiry+"\n"), 0600)).To(Succeed())
+			run()
+			Eventually(mockLogLevelManager.SetForCallCount).Should(Equal(1))
+			_, level, duration := mockLogLevelManager.SetForArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(3)))
+			Expect(duration).To(BeNumerically("~", time.Hour, time.Second))
+			Expect(mockLogLevelManager.SetCallCount()).To(Equal(0))
+		})
+
+		It("applies level only once if content does not change", func() {
+			Expect(os.WriteFile(path, []byte("v=3\n"), 0600)).To(Succeed())
+			run()
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(1))
+			Consistently(mockLogLevelManager.SetCallCount, 100*time.Millisecond).Should(Equal(1))
+		})
+
+		It("applies unchanged content again after the auto-reset", func() {
+			Expect(os.WriteFile(path, []byte("v=3\n"), 0600)).To(Succeed())
+			run()
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(1))
+			mockLogLevelManager.StateReturns(log.LogLevelState{})
+			Eventually(mockLogLevelManager.SetCallCount).Should(BeNumerically(">=", 2))
+		})
+
+		It("resets level if the file is deleted", func() {
+			Expect(os.WriteFile(path, []byte("v=3\nvmodule=kafka=3\n"), 0600)).To(Succeed())
+			run()
+			Eventually(vmodule).Should(Equal("kafka=3"))
+			Expect(mockLogLevelManager.SetCallCount()).To(Equal(1))
+
+			Expect(os.Remove(path)).To(Succeed())
+			Eventually(mockLogLevelManager.ResetCallCount).Should(Equal(1))
+			Expect(vmodule()).To(Equal("initial=1"))
+			Consistently(mockLogLevelManager.ResetCallCount, 50*time.Millisecond).Should(Equal(1))
+		})
+
+		It("resets level if the key is removed", func() {
+			Expect(os.WriteFile(path, []byte("v=3\n"), 0600)).To(Succeed())
+			run()
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(1))
+			Expect(os.WriteFile(path, []byte("vmodule=kafka=3\n"), 0600)).To(Succeed())
+			Eventually(mockLogLevelManager.ResetCallCount).Should(Equal(1))
+		})
+
+		It("follows atomic symlink swaps", func() {
+			swap("..2026_10_18_01", "v=2\n")
+			Expect(os.Symlink(filepath.Join("..data", "loglevel"), path)).To(Succeed())
+			run()
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(1))
+
+			swap("..2026_10_18_02", "v=5\n")
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(2))
+			_, level := mockLogLevelManager.SetArgsForCall(1)
+			Expect(level).To(Equal(glog.Level(5)))
+		})
+
+		It("waits for missing file", func() {
+			run()
+			Consistently(mockLogLevelManager.SetCallCount, 50*time.Millisecond).Should(Equal(0))
+			Expect(os.WriteFile(path, []byte("v=4\n"), 0600)).To(Succeed())
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(1))
+			Expect(mockLogLevelManager.ResetCallCount()).To(Equal(0))
+		})
+
+		It("ignores invalid content and keeps running", func() {
+			Expect(os.WriteFile(path, []byte("v=banana\n"), 0600)).To(Succeed())
+			run()
+			Consistently(mockLogLevelManager.SetCallCount, 50*time.Millisecond).Should(Equal(0))
+			Expect(os.WriteFile(path, []byte("v=4\n"), 0600)).To(Succeed())
+			Eventually(mockLogLevelManager.SetCallCount).Should(Equal(1))
+		})
+
+		It("ignores expired content", func() {
+			Expect(os.WriteFile(path, []byte("v=4\nexpiry=2000-01-01T00:00:00Z\n"), 0600)).
+				To(Succeed())
+			run()
+			Consistently(mockLogLevelManager.SetCallCount, 50*time.Millisecond).Should(Equal(0))
+			Expect(mockLogLevelManager.SetForCallCount()).To(Equal(0))
+		})
+
+		It("sets and restores vmodule", func() {
+			Expect(os.WriteFile(path, []byte("vmodule=kafka=3\n"), 0600)).To(Succeed())
+			run()
+			Eventually(vmodule).Should(Equal("kafka=3"))
+
+			Expect(os.WriteFile(path, []byte("v=1\n"), 0600)).To(Succeed())
+			Eventually(vmodule).Should(Equal("initial=1"))
+		})
+
+		It("resets v and vmodule after expiry", func() {
+			expiry := time.Now().Add(100 * time.Millisecond).UTC().Format(time.RFC3339Nano)
+			Expect(os.WriteFile(path, []byte("v=3\nvmodule=kafka=3\nexpiry="+expiry+"\n"), 0600)).
+				To(Succeed())
+			run()
+			Eventually(vmodule).Should(Equal("kafka=3"))
+			Expect(mockLogLevelManager.SetForCallCount()).To(Equal(1))
+			Eventually(vmodule).Should(Equal("initial=1"))
+			Expect(mockLogLevelManager.ResetCallCount()).To(Equal(1))
+			Consistently(mockLogLevelManager.SetForCallCount, 50*time.Millisecond).Should(Equal(1))
+		})
+
+		It("resets v and vmodule on stop", func() {
+			Expect(os.WriteFile(path, []byte("v=3\nvmodule=kafka=3\n"), 0600)).To(Succeed())
+			run()
+			Eventually(vmodule).Should(Equal("kafka=3"))
+			cancel()
+			Eventually(mockLogLevelManager.ResetCallCount).Should(Equal(1))
+			Eventually(vmodule).Should(Equal("initial=1"))
+		})
+	})
+})
+
+// syncStringValue is a flag.Value safe for concurrent use like the glog flag "vmodule".
+type syncStringValue struct {
+	mux   sync.Mutex
+	value string
+}
+
+func (s *syncStringValue) String() string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.value
+}
+
+func (s *syncStringValue) Set(value string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.value = value
+	return nil
+}
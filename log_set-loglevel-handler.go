@@ -8,14 +8,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strconv"
-
-	"github.com/golang/glog"
-	"github.com/gorilla/mux"
 )
 
 // NewSetLoglevelHandler creates an HTTP handler for dynamically changing log levels via REST API.
-// The handler expects a URL path variable named "level" containing the desired log level.
+// The handler expects a gorilla/mux URL path variable named "level" containing the desired log level.
+// Use NewLogLevelHandler for http.ServeMux or other routers.
 //
 // Usage with gorilla/mux:
 //
@@ -25,8 +22,17 @@ import (
 //
 // Example HTTP requests:
 //
-//	GET  /debug/loglevel/4  - Set log level to 4
-//	POST /debug/loglevel/2  - Set log level to 2
+//	POST /debug/loglevel/4  - Set log level to 4
+//	PUT  /debug/loglevel/2  - Set log level to 2
+//
+// Changing the log level changes state, so GET and HEAD requests are rejected with
+// 405 Method Not Allowed. Wrap the handler with NewAuthorizedHandler to restrict who
+// may change the log level:
+//
+//	router.Handle("/debug/loglevel/{level}", log.NewAuthorizedHandler(
+//	    log.NewAuthorizerBearerToken(token),
+//	    log.NewSetLoglevelHandler(ctx, logLevelSetter),
+//	))
 //
 // Parameters:
 //   - ctx: Context for the log level setter operations
@@ -34,21 +40,47 @@ import (
 //
 // Returns an http.Handler that can be registered with any HTTP router.
 func NewSetLoglevelHandler(ctx context.Context, logLevelSetter LogLevelSetter) http.Handler {
-	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
-		vars := mux.Vars(req)
-		level, err := strconv.ParseInt(vars["level"], 10, 32)
-		if err != nil {
-			fmt.Fprintf(resp, "parse loglevel failed: %v\n", err)
-			return
-		}
-		if err := logLevelSetter.Set(ctx, glog.Level(int32(level))); err != nil {
-			fmt.Fprintf(resp, "set loglevel failed: %v\n", err)
-			return
-		}
-		fmt.Fprintf(
-			resp,
-			"set loglevel to %d completed\n",
-			level,
-		) // #nosec G705 - level is an integer, not user-controlled string
-	})
+	return NewLogLevelHandler(ctx, logLevelSetter, NewLogLevelMuxVarsParser("level"))
+}
+
+// NewLogLevelHandler creates an HTTP handler that changes the log level to the level
+// extracted from the request by the given LogLevelParser.
+//
+// Usage with the standard library http.ServeMux (Go 1.22+):
+//
+//	mux := http.NewServeMux()
+//	mux.Handle("POST /debug/loglevel/{level}", log.NewLogLevelHandler(
+//	    ctx,
+//	    logLevelSetter,
+//	    log.NewLogLevelPathValueParser("level"),
+//	))
+//
+// Like NewSetLoglevelHandler it rejects GET and HEAD requests with 405 Method Not Allowed.
+// Levels that cannot be parsed are rejected with 400 Bad Request.
+func NewLogLevelHandler(
+	ctx context.Context,
+	logLevelSetter LogLevelSetter,
+	logLevelParser LogLevelParser,
+) http.Handler {
+	return newStateChangingHandler(
+		http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+			level, err := logLevelParser.ParseLogLevel(req)
+			if err != nil {
+				resp.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(resp, "parse loglevel failed: %v\n", err)
+				return
+			}
+			err = logLevelSetter.Set(WithLogLevelChangeSource(ctx, LogLevelChangeSourceHTTP), level)
+			if err != nil {
+				resp.WriteHeader(http.StatusInternalServerError)
+				fmt.Fprintf(resp, "set loglevel failed: %v\n", err)
+				return
+			}
+			fmt.Fprintf(
+				resp,
+				"set loglevel to %d completed\n",
+				level,
+			) // #nosec G705 - level is an integer, not user-controlled string
+		}),
+	)
 }
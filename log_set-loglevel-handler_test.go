@@ -46,7 +46,8 @@ var _ = Describe("Log SetLoglevelHandler", func() {
 			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(1))
 
 			actualCtx, actualLevel := mockLogLevelSetter.SetArgsForCall(0)
-			Expect(actualCtx).To(Equal(ctx))
+			Expect(log.LogLevelChangeSourceFromContext(actualCtx)).
+				To(Equal(log.LogLevelChangeSourceHTTP))
 			Expect(actualLevel).To(Equal(glog.Level(3)))
 		})
 
@@ -91,7 +92,7 @@ var _ = Describe("Log SetLoglevelHandler", func() {
 
 			handler.ServeHTTP(resp, req)
 
-			Expect(resp.Code).To(Equal(http.StatusOK)) // Handler doesn't set error status codes
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
 			Expect(resp.Body.String()).To(ContainSubstring("parse loglevel failed:"))
 			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
 		})
@@ -103,7 +104,7 @@ var _ = Describe("Log SetLoglevelHandler", func() {
 
 			handler.ServeHTTP(resp, req)
 
-			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
 			Expect(resp.Body.String()).To(ContainSubstring("parse loglevel failed:"))
 			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
 		})
@@ -115,7 +116,7 @@ var _ = Describe("Log SetLoglevelHandler", func() {
 
 			handler.ServeHTTP(resp, req)
 
-			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
 			Expect(resp.Body.String()).To(ContainSubstring("parse loglevel failed:"))
 			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
 		})
@@ -148,7 +149,7 @@ var _ = Describe("Log SetLoglevelHandler", func() {
 
 			handler.ServeHTTP(resp, req)
 
-			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
 			Expect(resp.Body.String()).To(Equal("set loglevel failed: setter failed\n"))
 			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(1))
 		})
@@ -162,24 +163,33 @@ var _ = Describe("Log SetLoglevelHandler", func() {
 
 			handler.ServeHTTP(resp, req)
 
-			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
 			Expect(resp.Body.String()).To(ContainSubstring("parse loglevel failed:"))
 			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
 		})
 	})
 
 	Context("different HTTP methods", func() {
-		It("handles GET request", func() {
-			mockLogLevelSetter.SetReturns(nil)
-
+		It("rejects GET request", func() {
 			req := httptest.NewRequest("GET", "/loglevel/2", nil)
 			req = mux.SetURLVars(req, map[string]string{"level": "2"})
 			resp := httptest.NewRecorder()
 
 			handler.ServeHTTP(resp, req)
 
-			Expect(resp.Code).To(Equal(http.StatusOK))
-			Expect(resp.Body.String()).To(Equal("set loglevel to 2 completed\n"))
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
+		})
+
+		It("rejects HEAD request", func() {
+			req := httptest.NewRequest("HEAD", "/loglevel/2", nil)
+			req = mux.SetURLVars(req, map[string]string{"level": "2"})
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
 		})
 
 		It("handles PUT request", func() {
@@ -213,4 +223,41 @@ var _ = Describe("Log SetLoglevelHandler", func() {
 			Expect(actualLevel).To(Equal(glog.Level(6)))
 		})
 	})
+
+	Context("NewLogLevelHandler with http.ServeMux", func() {
+		BeforeEach(func() {
+			serveMux := http.NewServeMux()
+			serveMux.Handle("/loglevel/{level}", log.NewLogLevelHandler(
+				ctx,
+				mockLogLevelSetter,
+				log.NewLogLevelPathValueParser("level"),
+			))
+			handler = serveMux
+		})
+
+		It("sets log level from path value", func() {
+			mockLogLevelSetter.SetReturns(nil)
+
+			req := httptest.NewRequest("POST", "/loglevel/3", nil)
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal("set loglevel to 3 completed\n"))
+			_, actualLevel := mockLogLevelSetter.SetArgsForCall(0)
+			Expect(actualLevel).To(Equal(glog.Level(3)))
+		})
+
+		It("returns error for invalid level", func() {
+			req := httptest.NewRequest("POST", "/loglevel/banana", nil)
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(ContainSubstring("parse loglevel failed:"))
+			Expect(mockLogLevelSetter.SetCallCount()).To(Equal(0))
+		})
+	})
 })
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	logLevelCurrentDesc = prometheus.NewDesc(
+		"log_level_current",
+		"Current glog verbosity (-v).",
+		nil,
+		nil,
+	)
+	logLevelDefaultDesc = prometheus.NewDesc(
+		"log_level_default",
+		"Default glog verbosity the log level is reset to.",
+		nil,
+		nil,
+	)
+	logLevelAutoResetDesc = prometheus.NewDesc(
+		"log_level_auto_reset_remaining_seconds",
+		"Seconds until the log level is reset to the default, zero without override.",
+		nil,
+		nil,
+	)
+	logLevelChangesDesc = prometheus.NewDesc(
+		"log_level_changes_total",
+		"Number of log level changes by source.",
+		[]string{"source"},
+		nil,
+	)
+)
+
+// NewLogLevelCollector returns a prometheus.Collector that exposes the state of the
+// LogLevelManager returned by NewLogLevelSetter:
+//
+//	log_level_current                       current glog verbosity
+//	log_level_default                       default verbosity
+//	log_level_auto_reset_remaining_seconds  seconds until the auto-reset
+//	log_level_changes_total{source}         changes by source (http, signal, file, programmatic)
+//
+// Values are read on every scrape, so they are always in sync with the setter.
+//
+// Example:
+//
+//	logLevelSetter := log.NewLogLevelSetter(glog.Level(1), 5*time.Minute)
+//	prometheus.MustRegister(log.NewLogLevelCollector(logLevelSetter))
+//
+// An alert on log_level_current > log_level_default finds services left at debug verbosity.
+func NewLogLevelCollector(logLevelStateGetter LogLevelStateGetter) prometheus.Collector {
+	return &logLevelCollector{
+		logLevelStateGetter: logLevelStateGetter,
+	}
+}
+
+type logLevelCollector struct {
+	logLevelStateGetter LogLevelStateGetter
+}
+
+func (l *logLevelCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- logLevelCurrentDesc
+	ch <- logLevelDefaultDesc
+	ch <- logLevelAutoResetDesc
+	ch <- logLevelChangesDesc
+}
+
+func (l *logLevelCollector) Collect(ch chan<- prometheus.Metric) {
+	state := l.logLevelStateGetter.State()
+	ch <- prometheus.MustNewConstMetric(
+		logLevelCurrentDesc,
+		prometheus.GaugeValue,
+		float64(state.LogLevel),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		logLevelDefaultDesc,
+		prometheus.GaugeValue,
+		float64(state.DefaultLogLevel),
+	)
+	ch <- prometheus.MustNewConstMetric(
+		logLevelAutoResetDesc,
+		prometheus.GaugeValue,
+		state.AutoResetIn.Seconds(),
+	)
+
+	// report all known sources, so counters exist before the first change
+	changes := map[LogLevelChangeSource]uint64{
+		LogLevelChangeSourceHTTP:         0,
+		LogLevelChangeSourceSignal:       0,
+		LogLevelChangeSourceFile:         0,
+		LogLevelChangeSourceProgrammatic: 0,
+	}
+	for source, count := range state.Changes {
+		changes[source] = count
+	}
+	for source, count := range changes {
+		ch <- prometheus.MustNewConstMetric(
+			logLevelChangesDesc,
+			prometheus.CounterValue,
+			float64(count),
+			string(source),
+		)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log LogLevelCollector", func() {
+	var logLevelStateGetter *mocks.LogLevelStateGetter
+	var registry *prometheus.Registry
+
+	BeforeEach(func() {
+		logLevelStateGetter = &mocks.LogLevelStateGetter{}
+		logLevelStateGetter.StateReturns(log.LogLevelState{
+			LogLevel:        4,
+			DefaultLogLevel: 1,
+			AutoResetIn:     90 * time.Second,
+			Changes: map[log.LogLevelChangeSource]uint64{
+				log.LogLevelChangeSourceHTTP: 3,
+			},
+		})
+		registry = prometheus.NewRegistry()
+		Expect(registry.Register(log.NewLogLevelCollector(logLevelStateGetter))).To(Succeed())
+	})
+
+	It("exposes state", func() {
+		Expect(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP log_level_auto_reset_remaining_seconds Seconds until the log level is reset to the default, zero without override.
+# TYPE log_level_auto_reset_remaining_seconds gauge
+log_level_auto_reset_remaining_seconds 90
+# HELP log_level_changes_total Number of log level changes by source.
+# TYPE log_level_changes_total counter
+log_level_changes_total{source="file"} 0
+log_level_changes_total{source="http"} 3
+log_level_changes_total{source="programmatic"} 0
+log_level_changes_total{source="signal"} 0
+# HELP log_level_current Current glog verbosity (-v).
+# TYPE log_level_current gauge
+log_level_current 4
+# HELP log_level_default Default glog verbosity the log level is reset to.
+# TYPE log_level_default gauge
+log_level_default 1
+`))).To(Succeed())
+	})
+
+	It("reads state on every scrape", func() {
+		_, err := registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = registry.Gather()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(logLevelStateGetter.StateCallCount()).To(Equal(2))
+	})
+})
@@ -0,0 +1,141 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/bborbe/errors"
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+)
+
+// maxLogLevelBodySize limits how much of a request body is read to parse a log level.
+const maxLogLevelBodySize = 1024
+
+//counterfeiter:generate -o mocks/log-loglevel-parser.go --fake-name LogLevelParser . LogLevelParser
+
+// LogLevelParser extracts the requested log level from an HTTP request.
+// It decouples NewLogLevelHandler from the router used to register it.
+type LogLevelParser interface {
+	// ParseLogLevel returns the log level contained in the request.
+	ParseLogLevel(req *http.Request) (glog.Level, error)
+}
+
+// LogLevelParserFunc is a function type that implements the LogLevelParser interface.
+type LogLevelParserFunc func(req *http.Request) (glog.Level, error)
+
+// ParseLogLevel implements the LogLevelParser interface by calling the underlying function.
+func (l LogLevelParserFunc) ParseLogLevel(req *http.Request) (glog.Level, error) {
+	return l(req)
+}
+
+// LogLevelParserList tries all contained parsers in order and returns the first level found.
+// If no parser succeeds the error of the last parser is returned.
+//
+// Example:
+//
+//	// Accept /loglevel/4, /loglevel?level=4 and a body containing 4
+//	parser := log.LogLevelParserList{
+//	    log.NewLogLevelPathValueParser("level"),
+//	    log.NewLogLevelQueryParser("level"),
+//	    log.NewLogLevelBodyParser(),
+//	}
+type LogLevelParserList []LogLevelParser
+
+// ParseLogLevel implements the LogLevelParser interface.
+func (l LogLevelParserList) ParseLogLevel(req *http.Request) (glog.Level, error) {
+	if len(l) == 0 {
+		return 0, errors.New(req.Context(), "no log level parser configured")
+	}
+	var err error
+	for _, parser := range l {
+		var level glog.Level
+		level, err = parser.ParseLogLevel(req)
+		if err == nil {
+			return level, nil
+		}
+	}
+	return 0, err
+}
+
+// NewLogLevelPathValueParser creates a LogLevelParser that reads the level from a path
+// wildcard of the standard library http.ServeMux (Go 1.22+), e.g. "POST /debug/loglevel/{level}".
+func NewLogLevelPathValueParser(name string) LogLevelParser {
+	return LogLevelParserFunc(func(req *http.Request) (glog.Level, error) {
+		return parseLogLevel(req, req.PathValue(name))
+	})
+}
+
+// NewLogLevelMuxVarsParser creates a LogLevelParser that reads the level from a
+// gorilla/mux route variable, e.g. "/debug/loglevel/{level}".
+func NewLogLevelMuxVarsParser(name string) LogLevelParser {
+	return LogLevelParserFunc(func(req *http.Request) (glog.Level, error) {
+		return parseLogLevel(req, mux.Vars(req)[name])
+	})
+}
+
+// NewLogLevelQueryParser creates a LogLevelParser that reads the level from a query
+// parameter, e.g. "/debug/loglevel?level=4".
+func NewLogLevelQueryParser(name string) LogLevelParser {
+	return LogLevelParserFunc(func(req *http.Request) (glog.Level, error) {
+		return parseLogLevel(req, req.URL.Query().Get(name))
+	})
+}
+
+// NewLogLevelBodyParser creates a LogLevelParser that reads the level from the request body.
+// The body may either contain the plain level ("4") or a JSON object ({"level":4}).
+func NewLogLevelBodyParser() LogLevelParser {
+	return LogLevelParserFunc(func(req *http.Request) (glog.Level, error) {
+		if req.Body == nil {
+			return parseLogLevel(req, "")
+		}
+		content, err := io.ReadAll(io.LimitReader(req.Body, maxLogLevelBodySize))
+		if err != nil {
+			return 0, errors.Wrapf(req.Context(), err, "read body failed")
+		}
+		value := strings.TrimSpace(string(content))
+		if strings.HasPrefix(value, "{") {
+			var data struct {
+				Level *int32 `json:"level"`
+			}
+			if err := json.Unmarshal(content, &data); err != nil {
+				return 0, errors.Wrapf(req.Context(), err, "decode json body failed")
+			}
+			if data.Level == nil {
+				return 0, errors.New(req.Context(), "level missing in json body")
+			}
+			return glog.Level(*data.Level), nil
+		}
+		return parseLogLevel(req, value)
+	})
+}
+
+// NewLogLevelParser creates the LogLevelParser used by RegisterAdminRoutes.
+// It accepts the level as path wildcard "level" of http.ServeMux or gorilla/mux,
+// as query parameter "level" or in the request body.
+func NewLogLevelParser() LogLevelParser {
+	return LogLevelParserList{
+		NewLogLevelPathValueParser("level"),
+		NewLogLevelMuxVarsParser("level"),
+		NewLogLevelQueryParser("level"),
+		NewLogLevelBodyParser(),
+	}
+}
+
+func parseLogLevel(req *http.Request, value string) (glog.Level, error) {
+	if value == "" {
+		return 0, errors.New(req.Context(), "level missing")
+	}
+	level, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(req.Context(), err, "parse level '%s' failed", value)
+	}
+	return glog.Level(int32(level)), nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/gorilla/mux"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log LogLevelParser", func() {
+	var req *http.Request
+	var level glog.Level
+	var err error
+
+	Context("NewLogLevelPathValueParser", func() {
+		BeforeEach(func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel/4", nil)
+		})
+		It("returns level from path value", func() {
+			req.SetPathValue("level", "4")
+			level, err = log.NewLogLevelPathValueParser("level").ParseLogLevel(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(level).To(Equal(glog.Level(4)))
+		})
+		It("returns error if path value is missing", func() {
+			_, err = log.NewLogLevelPathValueParser("level").ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+		It("returns error if path value is invalid", func() {
+			req.SetPathValue("level", "banana")
+			_, err = log.NewLogLevelPathValueParser("level").ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("NewLogLevelMuxVarsParser", func() {
+		It("returns level from mux vars", func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel/3", nil)
+			req = mux.SetURLVars(req, map[string]string{"level": "3"})
+			level, err = log.NewLogLevelMuxVarsParser("level").ParseLogLevel(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(level).To(Equal(glog.Level(3)))
+		})
+		It("returns error without mux vars", func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel/3", nil)
+			_, err = log.NewLogLevelMuxVarsParser("level").ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("NewLogLevelQueryParser", func() {
+		It("returns level from query", func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel?level=5", nil)
+			level, err = log.NewLogLevelQueryParser("level").ParseLogLevel(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(level).To(Equal(glog.Level(5)))
+		})
+		It("returns error without query parameter", func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel", nil)
+			_, err = log.NewLogLevelQueryParser("level").ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("NewLogLevelBodyParser", func() {
+		It("returns level from plain body", func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel", strings.NewReader("2\n"))
+			level, err = log.NewLogLevelBodyParser().ParseLogLevel(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(level).To(Equal(glog.Level(2)))
+		})
+		It("returns level from json body", func() {
+			req = httptest.NewRequest(
+				http.MethodPost,
+				"/debug/loglevel",
+				strings.NewReader(`{"level":6}`),
+			)
+			level, err = log.NewLogLevelBodyParser().ParseLogLevel(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(level).To(Equal(glog.Level(6)))
+		})
+		It("returns error for json body without level", func() {
+			req = httptest.NewRequest(
+				http.MethodPost,
+				"/debug/loglevel",
+				strings.NewReader(`{"other":6}`),
+			)
+			_, err = log.NewLogLevelBodyParser().ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+		It("returns error for invalid json body", func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel", strings.NewReader(`{`))
+			_, err = log.NewLogLevelBodyParser().ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+		It("returns error for empty body", func() {
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel", nil)
+			_, err = log.NewLogLevelBodyParser().ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("LogLevelParserList", func() {
+		var first *mocks.LogLevelParser
+		var second *mocks.LogLevelParser
+		BeforeEach(func() {
+			first = &mocks.LogLevelParser{}
+			second = &mocks.LogLevelParser{}
+			req = httptest.NewRequest(http.MethodPost, "/debug/loglevel", nil)
+		})
+		It("returns level of first successful parser", func() {
+			first.ParseLogLevelReturns(0, errors.New("banana"))
+			second.ParseLogLevelReturns(3, nil)
+			level, err = log.LogLevelParserList{first, second}.ParseLogLevel(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(level).To(Equal(glog.Level(3)))
+		})
+		It("stops after first successful parser", func() {
+			first.ParseLogLevelReturns(1, nil)
+			level, err = log.LogLevelParserList{first, second}.ParseLogLevel(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(level).To(Equal(glog.Level(1)))
+			Expect(second.ParseLogLevelCallCount()).To(Equal(0))
+		})
+		It("returns last error if all parsers fail", func() {
+			first.ParseLogLevelReturns(0, errors.New("banana"))
+			second.ParseLogLevelReturns(0, errors.New("apple"))
+			_, err = log.LogLevelParserList{first, second}.ParseLogLevel(req)
+			Expect(err).To(MatchError("apple"))
+		})
+		It("returns error if empty", func() {
+			_, err = log.LogLevelParserList{}.ParseLogLevel(req)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
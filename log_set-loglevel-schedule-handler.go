@@ -0,0 +1,161 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bborbe/errors"
+	"github.com/golang/glog"
+)
+
+// logLevelWindowJSON is the JSON representation of a LogLevelWindow.
+// Duration is a Go duration string like "30m".
+type logLevelWindowJSON struct {
+	ID       string     `json:"id,omitempty"`
+	LogLevel glog.Level `json:"loglevel"`
+	Start    *time.Time `json:"start,omitempty"`
+	End      *time.Time `json:"end,omitempty"`
+	Cron     string     `json:"cron,omitempty"`
+	Duration string     `json:"duration,omitempty"`
+}
+
+func newLogLevelWindowJSON(window LogLevelWindow) logLevelWindowJSON {
+	result := logLevelWindowJSON{
+		ID:       window.ID,
+		LogLevel: window.LogLevel,
+		Cron:     window.Cron,
+	}
+	if !window.Start.IsZero() {
+		result.Start = &window.Start
+	}
+	if !window.End.IsZero() {
+		result.End = &window.End
+	}
+	if window.Duration > 0 {
+		result.Duration = window.Duration.String()
+	}
+	return result
+}
+
+func (l logLevelWindowJSON) window(ctx context.Context) (LogLevelWindow, error) {
+	result := LogLevelWindow{
+		ID:       l.ID,
+		LogLevel: l.LogLevel,
+		Cron:     l.Cron,
+	}
+	if l.Start != nil {
+		result.Start = *l.Start
+	}
+	if l.End != nil {
+		result.End = *l.End
+	}
+	if l.Duration != "" {
+		duration, err := time.ParseDuration(l.Duration)
+		if err != nil {
+			return LogLevelWindow{}, errors.Wrapf(
+				ctx,
+				err,
+				"parse duration '%s' failed",
+				l.Duration,
+			)
+		}
+		result.Duration = duration
+	}
+	return result, nil
+}
+
+// NewLogLevelScheduleHandler creates an HTTP handler to manage the windows of a LogLevelScheduler:
+//
+//	GET    - list all windows as JSON
+//	POST   - add the window in the JSON body, responds with the stored window
+//	DELETE - remove the window with the ID from path variable "id" or query parameter "id"
+//
+// Example bodies:
+//
+//	{"loglevel":4,"start":"2026-10-18T02:00:00Z","end":"2026-10-18T02:30:00Z"}
+//	{"loglevel":4,"cron":"0 2 * * *","duration":"30m"}
+func NewLogLevelScheduleHandler(logLevelScheduler LogLevelScheduler) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		ctx := WithLogLevelChangeSource(req.Context(), LogLevelChangeSourceHTTP)
+		switch req.Method {
+		case http.MethodGet, http.MethodHead:
+			windows := logLevelScheduler.List(ctx)
+			result := make([]logLevelWindowJSON, 0, len(windows))
+			for _, window := range windows {
+				result = append(result, newLogLevelWindowJSON(window))
+			}
+			writeJSON(resp, req, http.StatusOK, result)
+		case http.MethodPost:
+			var body logLevelWindowJSON
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				http.Error(
+					resp,
+					fmt.Sprintf("decode window failed: %v", err),
+					http.StatusBadRequest,
+				)
+				return
+			}
+			window, err := body.window(ctx)
+			if err != nil {
+				http.Error(resp, err.Error(), http.StatusBadRequest)
+				return
+			}
+			window, err = logLevelScheduler.Add(ctx, window)
+			if err != nil {
+				http.Error(resp, fmt.Sprintf("add window failed: %v", err), http.StatusBadRequest)
+				return
+			}
+			writeJSON(resp, req, http.StatusCreated, newLogLevelWindowJSON(window))
+		case http.MethodDelete:
+			id := requestVar(req, "id")
+			if id == "" {
+				http.Error(resp, "id missing", http.StatusBadRequest)
+				return
+			}
+			if err := logLevelScheduler.Remove(ctx, id); err != nil {
+				status := http.StatusInternalServerError
+				if errors.Is(err, ErrLogLevelWindowNotFound) {
+					status = http.StatusNotFound
+				}
+				http.Error(resp, fmt.Sprintf("remove window failed: %v", err), status)
+				return
+			}
+			resp.WriteHeader(http.StatusNoContent)
+		default:
+			resp.Header().Set("Allow", "GET, HEAD, POST, DELETE")
+			http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+// NewLogLevelScheduleAdminRoutes returns the routes to manage scheduled log level windows:
+//
+//	GET    {prefix}/loglevel-schedule       - list windows
+//	POST   {prefix}/loglevel-schedule       - add window
+//	DELETE {prefix}/loglevel-schedule/{id}  - remove window
+func NewLogLevelScheduleAdminRoutes(logLevelScheduler LogLevelScheduler) AdminRoutes {
+	handler := NewLogLevelScheduleHandler(logLevelScheduler)
+	return AdminRoutes{
+		{Pattern: "/loglevel-schedule/{id}", Handler: handler},
+		{Pattern: "/loglevel-schedule", Handler: handler},
+	}
+}
+
+// writeJSON writes value as JSON response, HEAD requests get the headers only.
+func writeJSON(resp http.ResponseWriter, req *http.Request, status int, value interface{}) {
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(status)
+	if req.Method == http.MethodHead {
+		return
+	}
+	if err := json.NewEncoder(resp).Encode(value); err != nil {
+		glog.V(2).Infof("encode json response failed: %v", err)
+	}
+}
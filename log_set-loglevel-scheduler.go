@@ -0,0 +1,258 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+// ErrLogLevelWindowNotFound is returned by LogLevelScheduler.Remove for unknown window IDs.
+var ErrLogLevelWindowNotFound = stderrors.New("log level window not found")
+
+// LogLevelWindow is a time window in which the log level is raised.
+// It is either one-off (Start and End) or recurring (Cron and Duration).
+type LogLevelWindow struct {
+	// ID identifies the window. It is assigned by LogLevelScheduler.Add if empty.
+	ID string
+	// LogLevel is the glog verbosity during the window.
+	LogLevel glog.Level
+	// Start and End define a one-off window.
+	Start time.Time
+	End   time.Time
+	// Cron defines the start of a recurring window in UTC, e.g. "0 2 * * *" for daily at 02:00.
+	// Fields are "minute hour day-of-month month day-of-week".
+	Cron string
+	// Duration is the length of each recurring window.
+	Duration time.Duration
+}
+
+// Validate returns an error if the window is neither a valid one-off nor a valid recurring window.
+func (w LogLevelWindow) Validate(ctx context.Context) error {
+	if w.Cron != "" {
+		if !w.Start.IsZero() || !w.End.IsZero() {
+			return errors.New(ctx, "window must not have cron and start/end")
+		}
+		if w.Duration <= 0 {
+			return errors.New(ctx, "recurring window requires duration > 0")
+		}
+		if _, err := parseCronSchedule(ctx, w.Cron); err != nil {
+			return errors.Wrapf(ctx, err, "invalid cron")
+		}
+		return nil
+	}
+	if w.Start.IsZero() || w.End.IsZero() {
+		return errors.New(ctx, "window requires start and end or cron and duration")
+	}
+	if !w.End.After(w.Start) {
+		return errors.New(ctx, "window end must be after start")
+	}
+	return nil
+}
+
+//counterfeiter:generate -o mocks/log-loglevel-scheduler.go --fake-name LogLevelScheduler . LogLevelScheduler
+
+// LogLevelScheduler raises the log level during scheduled windows,
+// e.g. "v=4 from 02:00 to 02:30 UTC" to reproduce nightly batch problems.
+type LogLevelScheduler interface {
+	// Add validates and stores the window and returns it with its ID.
+	Add(ctx context.Context, window LogLevelWindow) (LogLevelWindow, error)
+	// Remove deletes the window and releases its elevation if active.
+	Remove(ctx context.Context, id string) error
+	// List returns all windows sorted by ID.
+	List(ctx context.Context) []LogLevelWindow
+	// Run applies the windows every poll interval until the context is cancelled.
+	Run(ctx context.Context) error
+}
+
+// NewLogLevelScheduler creates a LogLevelScheduler that elevates the log level through the
+// given LogLevelElevator while a window is active. Overlapping windows are resolved by the
+// elevator: the highest level wins.
+//
+// Example:
+//
+//	logLevelSetter := log.NewLogLevelSetter(glog.Level(1), 5*time.Minute)
+//	scheduler := log.NewLogLevelScheduler(logLevelSetter, libtime.NewCurrentTime(), time.Minute)
+//	_, err := scheduler.Add(ctx, log.LogLevelWindow{
+//	    LogLevel: 4,
+//	    Cron:     "0 2 * * *",
+//	    Duration: 30 * time.Minute,
+//	})
+//	go scheduler.Run(ctx)
+func NewLogLevelScheduler(
+	logLevelElevator LogLevelElevator,
+	currentTimeGetter libtime.CurrentTimeGetter,
+	pollInterval time.Duration,
+) LogLevelScheduler {
+	return &logLevelScheduler{
+		logLevelElevator:  logLevelElevator,
+		currentTimeGetter: currentTimeGetter,
+		pollInterval:      pollInterval,
+		windows:           make(map[string]*scheduledLogLevelWindow),
+	}
+}
+
+type logLevelScheduler struct {
+	logLevelElevator  LogLevelElevator
+	currentTimeGetter libtime.CurrentTimeGetter
+	pollInterval      time.Duration
+
+	mux     sync.Mutex
+	windows map[string]*scheduledLogLevelWindow
+	nextID  uint64
+}
+
+type scheduledLogLevelWindow struct {
+	window   LogLevelWindow
+	cron     *cronSchedule
+	restore  func()
+	activeTo time.Time
+}
+
+// activeUntil returns the end of the occurrence active at now.
+func (s *scheduledLogLevelWindow) activeUntil(now time.Time) (time.Time, bool) {
+	if s.cron == nil {
+		return s.window.End, !now.Before(s.window.Start) && now.Before(s.window.End)
+	}
+	start, ok := s.cron.lastStart(now, s.window.Duration)
+	return start.Add(s.window.Duration), ok
+}
+
+func (l *logLevelScheduler) Add(
+	ctx context.Context,
+	window LogLevelWindow,
+) (LogLevelWindow, error) {
+	if err := window.Validate(ctx); err != nil {
+		return LogLevelWindow{}, errors.Wrapf(ctx, err, "validate window failed")
+	}
+	scheduled := &scheduledLogLevelWindow{window: window}
+	if window.Cron != "" {
+		scheduled.cron, _ = parseCronSchedule(ctx, window.Cron)
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if window.Cron == "" && !window.End.After(l.currentTimeGetter.Now()) {
+		return LogLevelWindow{}, errors.New(ctx, "window end is in the past")
+	}
+	if window.ID == "" {
+		l.nextID++
+		window.ID = strconv.FormatUint(l.nextID, 10)
+		scheduled.window.ID = window.ID
+	}
+	if _, ok := l.windows[window.ID]; ok {
+		return LogLevelWindow{}, errors.Errorf(ctx, "window with id '%s' already exists", window.ID)
+	}
+	l.windows[window.ID] = scheduled
+	glog.V(2).Infof("log level window %s added", window.ID)
+
+	l.applyLocked(ctx)
+	return window, nil
+}
+
+func (l *logLevelScheduler) Remove(ctx context.Context, id string) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	scheduled, ok := l.windows[id]
+	if !ok {
+		return errors.Wrapf(ctx, ErrLogLevelWindowNotFound, "remove window '%s' failed", id)
+	}
+	if scheduled.restore != nil {
+		scheduled.restore()
+	}
+	delete(l.windows, id)
+	glog.V(2).Infof("log level window %s removed", id)
+	return nil
+}
+
+func (l *logLevelScheduler) List(ctx context.Context) []LogLevelWindow {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	result := make([]LogLevelWindow, 0, len(l.windows))
+	for _, scheduled := range l.windows {
+		result = append(result, scheduled.window)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+func (l *logLevelScheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(l.pollInterval)
+	defer ticker.Stop()
+	defer l.releaseAll()
+
+	for {
+		l.apply(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *logLevelScheduler) apply(ctx context.Context) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.applyLocked(ctx)
+}
+
+// applyLocked starts elevations of windows that became active and releases ended ones.
+// Elevations also expire on their own at the end of the window.
+func (l *logLevelScheduler) applyLocked(ctx context.Context) {
+	now := l.currentTimeGetter.Now()
+	for id, scheduled := range l.windows {
+		until, active := scheduled.activeUntil(now)
+		if scheduled.restore != nil && (!active || !until.Equal(scheduled.activeTo)) {
+			scheduled.restore()
+			scheduled.restore = nil
+			glog.V(2).Infof("log level window %s ended", id)
+		}
+		if active && scheduled.restore == nil {
+			glog.V(2).Infof(
+				"log level window %s started => elevate loglevel to %d until %v",
+				id,
+				scheduled.window.LogLevel,
+				until.Format(time.RFC3339),
+			)
+			scheduled.restore = l.logLevelElevator.Elevate(
+				ctx,
+				scheduled.window.LogLevel,
+				until.Sub(now),
+			)
+			scheduled.activeTo = until
+		}
+		if !active && scheduled.cron == nil && !now.Before(scheduled.window.End) {
+			delete(l.windows, id)
+			glog.V(2).Infof("log level window %s expired and removed", id)
+		}
+	}
+}
+
+func (l *logLevelScheduler) releaseAll() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	for _, scheduled := range l.windows {
+		if scheduled.restore != nil {
+			scheduled.restore()
+			scheduled.restore = nil
+		}
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log LogLevelScheduler", func() {
+	var ctx context.Context
+	var mux sync.Mutex
+	var now time.Time
+	var currentTime libtime.CurrentTime
+	var logLevelElevator *mocks.LogLevelElevator
+	var restoreCallCount int
+	var scheduler log.LogLevelScheduler
+
+	setNow := func(t time.Time) {
+		mux.Lock()
+		defer mux.Unlock()
+		now = t
+		currentTime.SetNow(now)
+	}
+	restoreCalls := func() int {
+		mux.Lock()
+		defer mux.Unlock()
+		return restoreCallCount
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 18, 1, 0, 0, 0, time.UTC)
+		currentTime = libtime.NewCurrentTime()
+		currentTime.SetNow(now)
+		restoreCallCount = 0
+		logLevelElevator = &mocks.LogLevelElevator{}
+		logLevelElevator.ElevateReturns(func() {
+			mux.Lock()
+			defer mux.Unlock()
+			restoreCallCount++
+		})
+		scheduler = log.NewLogLevelScheduler(
+			logLevelElevator,
+			currentTime,
+			10*time.Millisecond,
+		)
+	})
+
+	Context("Validate", func() {
+		DescribeTable("window",
+			func(window log.LogLevelWindow, expectError bool) {
+				err := window.Validate(ctx)
+				if expectError {
+					Expect(err).To(HaveOccurred())
+				} else {
+					Expect(err).NotTo(HaveOccurred())
+				}
+			},
+			Entry(
+				"one-off",
+				log.LogLevelWindow{Start: time.Unix(0, 0), End: time.Unix(60, 0)},
+				false,
+			),
+			Entry(
+				"end before start",
+				log.LogLevelWindow{Start: time.Unix(60, 0), End: time.Unix(0, 0)},
+				true,
+			),
+			Entry("missing end", log.LogLevelWindow{Start: time.Unix(0, 0)}, true),
+			Entry("daily", log.LogLevelWindow{Cron: "0 2 * * *", Duration: time.Minute}, false),
+			Entry(
+				"lists, ranges and steps",
+				log.LogLevelWindow{Cron: "*/15 8-18 1,15 1-12/2 1-5", Duration: time.Minute},
+				false,
+			),
+			Entry(
+				"sunday as 7",
+				log.LogLevelWindow{Cron: "0 0 * * 7", Duration: time.Minute},
+				false,
+			),
+			Entry("missing duration", log.LogLevelWindow{Cron: "0 2 * * *"}, true),
+			Entry(
+				"too few fields",
+				log.LogLevelWindow{Cron: "0 2 * *", Duration: time.Minute},
+				true,
+			),
+			Entry(
+				"minute out of range",
+				log.LogLevelWindow{Cron: "60 2 * * *", Duration: time.Minute},
+				true,
+			),
+			Entry(
+				"invalid step",
+				log.LogLevelWindow{Cron: "*/0 2 * * *", Duration: time.Minute},
+				true,
+			),
+			Entry(
+				"invalid value",
+				log.LogLevelWindow{Cron: "a 2 * * *", Duration: time.Minute},
+				true,
+			),
+			Entry(
+				"cron and start",
+				log.LogLevelWindow{
+					Cron:     "0 2 * * *",
+					Duration: time.Minute,
+					Start:    time.Unix(0, 0),
+				},
+				true,
+			),
+		)
+	})
+
+	Context("Add", func() {
+		It("assigns ids", func() {
+			first, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Cron:     "0 2 * * *",
+				Duration: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			second, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Cron:     "0 3 * * *",
+				Duration: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first.ID).To(Equal("1"))
+			Expect(second.ID).To(Equal("2"))
+			Expect(scheduler.List(ctx)).To(HaveLen(2))
+		})
+		It("rejects duplicate ids", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				ID:       "a",
+				Cron:     "0 2 * * *",
+				Duration: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = scheduler.Add(ctx, log.LogLevelWindow{
+				ID:       "a",
+				Cron:     "0 2 * * *",
+				Duration: time.Minute,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+		It("rejects one-off window in the past", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				Start: now.Add(-time.Hour),
+				End:   now.Add(-time.Minute),
+			})
+			Expect(err).To(HaveOccurred())
+		})
+		It("elevates immediately if window is active", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Start:    now.Add(-time.Minute),
+				End:      now.Add(30 * time.Minute),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logLevelElevator.ElevateCallCount()).To(Equal(1))
+			_, level, duration := logLevelElevator.ElevateArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(4)))
+			Expect(duration).To(Equal(30 * time.Minute))
+		})
+		It("does not elevate before window starts", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Start:    now.Add(time.Hour),
+				End:      now.Add(2 * time.Hour),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logLevelElevator.ElevateCallCount()).To(Equal(0))
+		})
+		It("elevates for the remaining time of an active recurring window", func() {
+			setNow(time.Date(2026, 10, 18, 2, 10, 30, 0, time.UTC))
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 3,
+				Cron:     "0 2 * * *",
+				Duration: 30 * time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logLevelElevator.ElevateCallCount()).To(Equal(1))
+			_, level, duration := logLevelElevator.ElevateArgsForCall(0)
+			Expect(level).To(Equal(glog.Level(3)))
+			Expect(duration).To(Equal(19*time.Minute + 30*time.Second))
+		})
+		It("elevates every overlapping window", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 3,
+				Start:    now,
+				End:      now.Add(time.Hour),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			_, err = scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 5,
+				Start:    now,
+				End:      now.Add(time.Minute),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(logLevelElevator.ElevateCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("Remove", func() {
+		It("releases active window", func() {
+			window, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Start:    now,
+				End:      now.Add(time.Hour),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(scheduler.Remove(ctx, window.ID)).To(Succeed())
+			Expect(restoreCalls()).To(Equal(1))
+			Expect(scheduler.List(ctx)).To(BeEmpty())
+		})
+		It("returns ErrLogLevelWindowNotFound", func() {
+			err := scheduler.Remove(ctx, "unknown")
+			Expect(err).To(MatchError(log.ErrLogLevelWindowNotFound))
+		})
+	})
+
+	Context("Run", func() {
+		var cancel context.CancelFunc
+		var done chan struct{}
+
+		BeforeEach(func() {
+			var runCtx context.Context
+			runCtx, cancel = context.WithCancel(ctx)
+			done = make(chan struct{})
+			go func() {
+				defer close(done)
+				_ = scheduler.Run(runCtx)
+			}()
+		})
+
+		AfterEach(func() {
+			cancel()
+			Eventually(done).Should(BeClosed())
+		})
+
+		It("starts and ends a recurring window", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Cron:     "0 2 * * *",
+				Duration: 30 * time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Consistently(logLevelElevator.ElevateCallCount, 50*time.Millisecond).Should(Equal(0))
+
+			setNow(time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC))
+			Eventually(logLevelElevator.ElevateCallCount).Should(Equal(1))
+			_, _, duration := logLevelElevator.ElevateArgsForCall(0)
+			Expect(duration).To(Equal(30 * time.Minute))
+
+			setNow(time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC))
+			Eventually(restoreCalls).Should(Equal(1))
+
+			setNow(time.Date(2026, 10, 19, 2, 0, 0, 0, time.UTC))
+			Eventually(logLevelElevator.ElevateCallCount).Should(Equal(2))
+			Expect(scheduler.List(ctx)).To(HaveLen(1))
+		})
+
+		It("removes one-off window after it ended", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Start:    now,
+				End:      now.Add(time.Hour),
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			setNow(now.Add(time.Hour))
+			Eventually(restoreCalls).Should(Equal(1))
+			Eventually(func() []log.LogLevelWindow { return scheduler.List(ctx) }).Should(BeEmpty())
+		})
+
+		It("releases active windows when stopped", func() {
+			_, err := scheduler.Add(ctx, log.LogLevelWindow{
+				LogLevel: 4,
+				Start:    now,
+				End:      now.Add(time.Hour),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			cancel()
+			Eventually(done).Should(BeClosed())
+			Expect(restoreCalls()).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("Log LogLevelScheduleHandler", func() {
+	var logLevelScheduler *mocks.LogLevelScheduler
+	var handler http.Handler
+	var recorder *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		logLevelScheduler = &mocks.LogLevelScheduler{}
+		handler = log.NewLogLevelScheduleHandler(logLevelScheduler)
+		recorder = httptest.NewRecorder()
+	})
+
+	It("lists windows", func() {
+		logLevelScheduler.ListReturns([]log.LogLevelWindow{
+			{ID: "1", LogLevel: 4, Cron: "0 2 * * *", Duration: 30 * time.Minute},
+		})
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(
+			`[{"id":"1","loglevel":4,"cron":"0 2 * * *","duration":"30m0s"}]`,
+		))
+	})
+
+	It("adds window", func() {
+		logLevelScheduler.AddStub = func(
+			ctx context.Context,
+			window log.LogLevelWindow,
+		) (log.LogLevelWindow, error) {
+			window.ID = "7"
+			return window, nil
+		}
+		body := `{"loglevel":4,"start":"2026-10-18T02:00:00Z","end":"2026-10-18T02:30:00Z"}`
+		handler.ServeHTTP(
+			recorder,
+			httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)),
+		)
+		Expect(recorder.Code).To(Equal(http.StatusCreated))
+		Expect(logLevelScheduler.AddCallCount()).To(Equal(1))
+		_, window := logLevelScheduler.AddArgsForCall(0)
+		Expect(window.LogLevel).To(Equal(glog.Level(4)))
+		Expect(window.End.Sub(window.Start)).To(Equal(30 * time.Minute))
+		Expect(recorder.Body.String()).To(ContainSubstring(`"id":"7"`))
+	})
+
+	It("rejects invalid duration", func() {
+		body := `{"loglevel":4,"cron":"0 2 * * *","duration":"banana"}`
+		handler.ServeHTTP(
+			recorder,
+			httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)),
+		)
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(logLevelScheduler.AddCallCount()).To(Equal(0))
+	})
+
+	It("removes window by query parameter", func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/?id=3", nil))
+		Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		_, id := logLevelScheduler.RemoveArgsForCall(0)
+		Expect(id).To(Equal("3"))
+	})
+
+	It("removes window by path value", func() {
+		serveMux := http.NewServeMux()
+		log.RegisterAdminRoutes(
+			serveMux,
+			"/debug",
+			log.NewAuthorizerAllowAll(),
+			log.NewLogLevelScheduleAdminRoutes(logLevelScheduler),
+		)
+		serveMux.ServeHTTP(
+			recorder,
+			httptest.NewRequest(http.MethodDelete, "/debug/loglevel-schedule/5", nil),
+		)
+		Expect(recorder.Code).To(Equal(http.StatusNoContent))
+		_, id := logLevelScheduler.RemoveArgsForCall(0)
+		Expect(id).To(Equal("5"))
+	})
+
+	It("returns not found for unknown window", func() {
+		logLevelScheduler.RemoveReturns(log.ErrLogLevelWindowNotFound)
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, "/?id=3", nil))
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("rejects other methods", func() {
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/", nil))
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
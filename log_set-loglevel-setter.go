@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/bborbe/errors"
 	libtime "github.com/bborbe/time"
 	"github.com/golang/glog"
 )
@@ -38,6 +39,100 @@ func (l LogLevelSetterFunc) Set(ctx context.Context, logLevel glog.Level) error
 	return l(ctx, logLevel)
 }
 
+// LogLevelChangeFunc is called with the old and the new glog verbosity after the log level changed.
+type LogLevelChangeFunc func(oldLevel glog.Level, newLevel glog.Level)
+
+//counterfeiter:generate -o mocks/log-loglevel-subscriber.go --fake-name LogLevelSubscriber . LogLevelSubscriber
+
+// LogLevelSubscriber allows other components to follow log level changes,
+// e.g. to keep slog or third-party loggers in sync with glog.
+type LogLevelSubscriber interface {
+	// Subscribe registers fn to be called after every log level change, including auto-resets.
+	// fn is called synchronously and must not call back into the LogLevelSetter.
+	// The returned function removes the subscription.
+	Subscribe(fn LogLevelChangeFunc) (unsubscribe func())
+}
+
+//counterfeiter:generate -o mocks/log-loglevel-elevator.go --fake-name LogLevelElevator . LogLevelElevator
+
+// LogLevelElevator raises the log level temporarily for a scope, e.g. a single retry of a failed job.
+type LogLevelElevator interface {
+	// Elevate raises the log level to at least logLevel until the returned restore function
+	// is called or the duration expired, whichever comes first.
+	// Overlapping elevations combine: the highest level wins and the level drops back
+	// only after the last elevation is released or expired.
+	Elevate(ctx context.Context, logLevel glog.Level, duration time.Duration) (restore func())
+}
+
+//counterfeiter:generate -o mocks/log-loglevel-manager.go --fake-name LogLevelManager . LogLevelManager
+
+// LogLevelManager is the LogLevelSetter returned by NewLogLevelSetter.
+// Next to changing the log level it allows subscribing to changes and scoped elevation.
+type LogLevelManager interface {
+	LogLevelSetter
+	LogLevelSubscriber
+	LogLevelElevator
+	LogLevelStateGetter
+	// SetFor changes the log level like Set, but resets it after duration instead of the
+	// auto-reset duration, e.g. at the expiry of a file-based override.
+	SetFor(ctx context.Context, logLevel glog.Level, duration time.Duration) error
+	// Reset sets the log level back to the default immediately.
+	Reset(ctx context.Context) error
+	// Restore applies the override stored in the LogLevelStore, if it is not expired yet.
+	// Expired overrides are deleted. Without LogLevelStore Restore does nothing.
+	Restore(ctx context.Context) error
+}
+
+// LogLevelState is a snapshot of the state of a LogLevelManager.
+type LogLevelState struct {
+	// LogLevel is the current glog verbosity.
+	LogLevel glog.Level
+	// DefaultLogLevel is the level the auto-reset returns to.
+	DefaultLogLevel glog.Level
+	// AutoResetIn is the time left until the override set by Set is reset, zero without override.
+	AutoResetIn time.Duration
+	// Changes counts Set, Elevate and Restore calls by LogLevelChangeSource.
+	Changes map[LogLevelChangeSource]uint64
+}
+
+//counterfeiter:generate -o mocks/log-loglevel-state-getter.go --fake-name LogLevelStateGetter . LogLevelStateGetter
+
+// LogLevelStateGetter returns the current LogLevelState, e.g. for metrics.
+type LogLevelStateGetter interface {
+	State() LogLevelState
+}
+
+// LogLevelSetterOption configures optional features of NewLogLevelSetter.
+type LogLevelSetterOption func(*logLevelSetter)
+
+// WithLogLevelStore persists every override set by Set together with its absolute expiry,
+// so Restore can re-apply it after a restart. The stored override is deleted on auto-reset,
+// Reset and Set of the default level.
+func WithLogLevelStore(logLevelStore LogLevelStore) LogLevelSetterOption {
+	return func(l *logLevelSetter) {
+		l.logLevelStore = logLevelStore
+	}
+}
+
+// WithLogLevelFlagSet changes the FlagSet holding the verbosity flag "v",
+// e.g. for klog-style setups that register the glog flags in their own FlagSet or for
+// tests with an isolated FlagSet. The default is flag.CommandLine.
+func WithLogLevelFlagSet(flagSet *flag.FlagSet) LogLevelSetterOption {
+	return func(l *logLevelSetter) {
+		l.flagSet = flagSet
+	}
+}
+
+// WithLogLevelCurrentTimeGetter changes the clock used for the auto-reset deadline and the
+// expiry of stored overrides. The default is libtime.NewCurrentTime.
+func WithLogLevelCurrentTimeGetter(
+	currentTimeGetter libtime.CurrentTimeGetter,
+) LogLevelSetterOption {
+	return func(l *logLevelSetter) {
+		l.currentTimeGetter = currentTimeGetter
+	}
+}
+
 // NewLogLevelSetter creates a new LogLevelSetter that automatically resets to the
 // default log level after the specified duration.
 //
@@ -46,56 +141,360 @@ func (l LogLevelSetterFunc) Set(ctx context.Context, logLevel glog.Level) error
 //   - autoResetDuration: How long to wait before automatically resetting the log level
 //
 // The setter is thread-safe and can handle concurrent log level changes.
+// Use Subscribe on the returned LogLevelManager to get notified about changes
+// and Elevate to raise the log level for a scope:
+//
+//	restore := logLevelSetter.Elevate(ctx, 4, 2*time.Minute)
+//	defer restore()
+//
+// The effective log level is the highest of the level set by Set (or the default after
+// the auto-reset) and all active elevations.
+//
+// Optional features are enabled with options, e.g. WithLogLevelStore or WithLogLevelFlagSet.
+// Set and Restore return an error if the flag "v" does not exist or rejects the value.
 func NewLogLevelSetter(
 	defaultLoglevel glog.Level,
 	autoResetDuration time.Duration,
-) LogLevelSetter {
-	return &logLevelSetter{
+	options ...LogLevelSetterOption,
+) LogLevelManager {
+	l := &logLevelSetter{
 		defaultLoglevel:   defaultLoglevel,
 		autoResetDuration: autoResetDuration,
+		flagSet:           flag.CommandLine,
+		currentTimeGetter: libtime.NewCurrentTime(),
+		baseLogLevel:      defaultLoglevel,
+		subscribers:       make(map[uint64]LogLevelChangeFunc),
+		elevations:        make(map[uint64]glog.Level),
+		changes:           make(map[LogLevelChangeSource]uint64),
 	}
-
+	for _, option := range options {
+		option(l)
+	}
+	// the base log level is a setting with auto-reset, its default is defaultLoglevel
+	l.settingOverrider = NewSettingOverrider(
+		l.currentTimeGetter,
+		autoResetDuration,
+		NewFuncSetting(logLevelSettingName, l.baseLogLevelValue, l.setBaseLogLevel),
+	)
+	return l
 }
 
+// logLevelSettingName is the name of the base log level in the SettingOverrider.
+const logLevelSettingName = "v"
+
 type logLevelSetter struct {
 	autoResetDuration time.Duration
 	defaultLoglevel   glog.Level
+	logLevelStore     LogLevelStore
+	flagSet           *flag.FlagSet
+	currentTimeGetter libtime.CurrentTimeGetter
+	settingOverrider  SettingOverrider
 
-	mux             sync.Mutex
-	lastSetTime     time.Time
-	currentLogLevel glog.Level
+	// storeMux serializes changes of the level together with the LogLevelStore,
+	// it is locked before the SettingOverrider and mux
+	storeMux sync.Mutex
+
+	mux              sync.Mutex
+	baseLogLevel     glog.Level
+	subscribers      map[uint64]LogLevelChangeFunc
+	nextSubscriberID uint64
+	elevations       map[uint64]glog.Level
+	nextElevationID  uint64
+	changes          map[LogLevelChangeSource]uint64
+}
+
+func (l *logLevelSetter) Subscribe(fn LogLevelChangeFunc) func() {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	id := l.nextSubscriberID
+	l.nextSubscriberID++
+	l.subscribers[id] = fn
+	return func() {
+		l.mux.Lock()
+		defer l.mux.Unlock()
+
+		delete(l.subscribers, id)
+	}
 }
 
 func (l *logLevelSetter) Set(ctx context.Context, logLevel glog.Level) error {
+	return l.SetFor(ctx, logLevel, l.autoResetDuration)
+}
+
+func (l *logLevelSetter) SetFor(
+	ctx context.Context,
+	logLevel glog.Level,
+	duration time.Duration,
+) error {
+	if duration <= 0 {
+		duration = l.autoResetDuration
+	}
+
+	// the stored override must match the active level, even for concurrent calls
+	l.storeMux.Lock()
+	defer l.storeMux.Unlock()
+
+	if err := l.override(ctx, logLevel, duration); err != nil {
+		return err
+	}
+	if l.logLevelStore == nil {
+		return nil
+	}
+	if logLevel == l.defaultLoglevel {
+		// nothing to restore after a restart
+		if err := l.logLevelStore.Delete(ctx); err != nil {
+			glog.Warningf("delete loglevel override failed: %v", err)
+		}
+		return nil
+	}
+	override := LogLevelOverride{
+		LogLevel: logLevel,
+		Expiry:   l.currentTimeGetter.Now().Add(duration),
+	}
+	if err := l.logLevelStore.Save(ctx, override); err != nil {
+		glog.Warningf("save loglevel override failed: %v", err)
+	}
+	return nil
+}
+
+func (l *logLevelSetter) Reset(ctx context.Context) error {
+	l.storeMux.Lock()
+	defer l.storeMux.Unlock()
+
+	if err := l.settingOverrider.Reset(ctx, logLevelSettingName); err != nil {
+		return errors.Wrapf(ctx, err, "reset loglevel to %d failed", l.defaultLoglevel)
+	}
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	l.countChange(ctx)
+	return nil
+}
+
+func (l *logLevelSetter) Restore(ctx context.Context) error {
+	if l.logLevelStore == nil {
+		return nil
+	}
+
+	l.storeMux.Lock()
+	defer l.storeMux.Unlock()
+
+	override, err := l.logLevelStore.Load(ctx)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "load loglevel override failed")
+	}
+	if override == nil {
+		return nil
+	}
+
+	now := l.currentTimeGetter.Now()
+	if !override.Expiry.After(now) {
+		glog.V(l.defaultLoglevel).Infof(
+			"loglevel override %d expired at %v => discard",
+			override.LogLevel,
+			override.Expiry.Format(time.RFC3339),
+		)
+		if err := l.logLevelStore.Delete(ctx); err != nil {
+			return errors.Wrapf(ctx, err, "delete expired loglevel override failed")
+		}
+		return nil
+	}
+	return l.override(ctx, override.LogLevel, override.Expiry.Sub(now))
+}
+
+// override sets the base log level through the SettingOverrider, which resets it to the
+// default after duration. If the flag rejects the level, the previous base log level is kept.
+func (l *logLevelSetter) override(
+	ctx context.Context,
+	logLevel glog.Level,
+	duration time.Duration,
+) error {
+	err := l.settingOverrider.Override(
+		ctx,
+		logLevelSettingName,
+		strconv.Itoa(int(logLevel)),
+		duration,
+	)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "set loglevel to %d failed", logLevel)
+	}
 	l.mux.Lock()
 	defer l.mux.Unlock()
 
-	l.lastSetTime = libtime.Now()
-	l.currentLogLevel = logLevel
+	l.countChange(ctx)
+	glog.V(l.defaultLoglevel).Infof(
+		"set loglevel to %d and reset in %v back to %d",
+		logLevel,
+		duration,
+		l.defaultLoglevel,
+	)
+	return nil
+}
+
+// baseLogLevelValue is the getter of the base log level setting.
+func (l *logLevelSetter) baseLogLevelValue() string {
+	l.mux.Lock()
+	defer l.mux.Unlock()
 
-	_ = flag.Set("v", strconv.Itoa(int(logLevel)))
+	return strconv.Itoa(int(l.baseLogLevel))
+}
 
-	glog.V(l.defaultLoglevel).
-		Infof("set loglevel to %d and reset in %v back to %d", logLevel, l.autoResetDuration, l.defaultLoglevel)
-	go func() { // #nosec G118 -- intentional: reset must outlive caller's context
-		ctx, cancel := context.WithTimeout(context.Background(), l.autoResetDuration)
-		defer cancel()
+// setBaseLogLevel is the setter of the base log level setting, called by the
+// SettingOverrider for overrides and resets. Once the level is back at the default,
+// the stored override is deleted.
+func (l *logLevelSetter) setBaseLogLevel(ctx context.Context, value string) error {
+	level, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "parse loglevel '%s' failed", value)
+	}
 
-		<-ctx.Done()
-		l.resetLogLevel()
-	}()
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	previousLogLevel := l.baseLogLevel
+	l.baseLogLevel = glog.Level(int32(level))
+	if err := l.apply(ctx); err != nil {
+		l.baseLogLevel = previousLogLevel
+		return err
+	}
+	if l.baseLogLevel != l.defaultLoglevel || l.logLevelStore == nil {
+		return nil
+	}
+	if err := l.logLevelStore.Delete(ctx); err != nil {
+		glog.Warningf("delete loglevel override failed: %v", err)
+	}
 	return nil
 }
 
-func (l *logLevelSetter) resetLogLevel() {
+func (l *logLevelSetter) Elevate(
+	ctx context.Context,
+	logLevel glog.Level,
+	duration time.Duration,
+) func() {
 	l.mux.Lock()
 	defer l.mux.Unlock()
 
-	if libtime.Now().Sub(l.lastSetTime) <= l.autoResetDuration {
-		glog.V(l.defaultLoglevel).Infof("time since lastSet is too short => skip reset loglevel")
+	if len(l.elevations) == 0 {
+		// without active elevations the flag holds the level to drop back to
+		l.baseLogLevel = l.currentLogLevel()
+	}
+	id := l.nextElevationID
+	l.nextElevationID++
+	l.elevations[id] = logLevel
+	if err := l.apply(ctx); err != nil {
+		delete(l.elevations, id)
+		glog.Warningf("elevate loglevel to %d failed: %v", logLevel, err)
+		return func() {}
+	}
+	l.countChange(ctx)
+
+	glog.V(l.defaultLoglevel).Infof(
+		"elevate loglevel to %d for %v (%d active elevations)",
+		logLevel,
+		duration,
+		len(l.elevations),
+	)
+
+	var once sync.Once
+	release := func() {
+		once.Do(func() { l.releaseElevation(id) })
+	}
+	timer := time.AfterFunc(duration, release)
+	return func() {
+		timer.Stop()
+		release()
+	}
+}
+
+func (l *logLevelSetter) releaseElevation(id uint64) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	delete(l.elevations, id)
+	if err := l.apply(context.Background()); err != nil {
+		glog.Warningf("release elevation failed: %v", err)
 		return
 	}
+	glog.V(l.defaultLoglevel).Infof(
+		"elevation released, loglevel is %d (%d active elevations)",
+		l.currentLogLevel(),
+		len(l.elevations),
+	)
+}
+
+func (l *logLevelSetter) State() LogLevelState {
+	// read the reset time first, the SettingOverrider calls back into the setter
+	var resetAt time.Time
+	for _, settingOverride := range l.settingOverrider.List(context.Background()) {
+		resetAt = settingOverride.ResetAt
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	state := LogLevelState{
+		LogLevel:        l.currentLogLevel(),
+		DefaultLogLevel: l.defaultLoglevel,
+		Changes:         make(map[LogLevelChangeSource]uint64, len(l.changes)),
+	}
+	if !resetAt.IsZero() {
+		state.AutoResetIn = max(resetAt.Sub(l.currentTimeGetter.Now()), 0)
+	}
+	for source, count := range l.changes {
+		state.Changes[source] = count
+	}
+	return state
+}
+
+// countChange counts a change for the LogLevelChangeSource of ctx.
+// The caller must hold the mutex.
+func (l *logLevelSetter) countChange(ctx context.Context) {
+	l.changes[LogLevelChangeSourceFromContext(ctx)]++
+}
+
+// apply sets the glog verbosity to the highest of the base log level and all elevations.
+// The caller must hold the mutex.
+func (l *logLevelSetter) apply(ctx context.Context) error {
+	logLevel := l.baseLogLevel
+	for _, elevation := range l.elevations {
+		logLevel = max(logLevel, elevation)
+	}
+	return l.setFlag(ctx, logLevel)
+}
+
+// setFlag changes the glog verbosity and notifies all subscribers.
+// The caller must hold the mutex.
+func (l *logLevelSetter) setFlag(ctx context.Context, logLevel glog.Level) error {
+	oldLogLevel := l.currentLogLevel()
+
+	if err := l.flagSet.Set("v", strconv.Itoa(int(logLevel))); err != nil {
+		return errors.Wrapf(ctx, err, "set flag v of %s failed", l.flagSet.Name())
+	}
 
-	_ = flag.Set("v", strconv.Itoa(int(l.defaultLoglevel)))
-	glog.V(l.defaultLoglevel).Infof("loglevel set back to %d", l.defaultLoglevel)
+	if oldLogLevel == logLevel {
+		return nil
+	}
+	for _, fn := range l.subscribers {
+		fn(oldLogLevel, logLevel)
+	}
+	return nil
+}
+
+// currentLogLevel returns the current verbosity of the flag "v" of the setter's FlagSet.
+func (l *logLevelSetter) currentLogLevel() glog.Level {
+	return flagSetLogLevel(l.flagSet)
+}
+
+// flagSetLogLevel returns the verbosity of the flag "v" in flagSet or 0 if it does not exist.
+func flagSetLogLevel(flagSet *flag.FlagSet) glog.Level {
+	f := flagSet.Lookup("v")
+	if f == nil {
+		return 0
+	}
+	level, err := strconv.ParseInt(f.Value.String(), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return glog.Level(int32(level))
 }
@@ -7,21 +7,32 @@ package log_test
 import (
 	"context"
 	"errors"
+	"flag"
+	"path/filepath"
+	"strconv"
+	"sync"
 	"time"
 
+	libtime "github.com/bborbe/time"
 	"github.com/golang/glog"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
 )
 
 var _ = Describe("Log LogLevelSetter", Serial, func() {
 	var logLevelSetter log.LogLevelSetter
 	var ctx context.Context
+	var flagSet *flag.FlagSet
 
 	BeforeEach(func() {
 		ctx = context.Background()
+		// isolated FlagSet, so auto-resets do not race with other tests
+		logLevel := glog.Level(1)
+		flagSet = flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.Var(&logLevel, "v", "log level for V logs")
 	})
 
 	Context("NewLogLevelSetter", func() {
@@ -50,6 +61,450 @@ var _ = Describe("Log LogLevelSetter", Serial, func() {
 		})
 	})
 
+	Context("Subscribe", func() {
+		var logLevelManager log.LogLevelManager
+		var changes [][2]glog.Level
+		var mux sync.Mutex
+
+		BeforeEach(func() {
+			changes = nil
+			logLevelManager = log.NewLogLevelSetter(
+				glog.Level(1),
+				50*time.Millisecond,
+				log.WithLogLevelFlagSet(flagSet),
+			)
+		})
+
+		subscribe := func() func() {
+			return logLevelManager.Subscribe(func(oldLevel glog.Level, newLevel glog.Level) {
+				mux.Lock()
+				defer mux.Unlock()
+				changes = append(changes, [2]glog.Level{oldLevel, newLevel})
+			})
+		}
+		getChanges := func() [][2]glog.Level {
+			mux.Lock()
+			defer mux.Unlock()
+			return changes
+		}
+
+		It("notifies about set and auto-reset", func() {
+			subscribe()
+			Expect(logLevelManager.Set(ctx, glog.Level(3))).To(Succeed())
+			Expect(getChanges()).To(Equal([][2]glog.Level{{1, 3}}))
+			Eventually(getChanges).Should(Equal([][2]glog.Level{{1, 3}, {3, 1}}))
+		})
+
+		It("does not notify if level is unchanged", func() {
+			subscribe()
+			Expect(logLevelManager.Set(ctx, glog.Level(1))).To(Succeed())
+			Consistently(getChanges, 100*time.Millisecond).Should(BeEmpty())
+		})
+
+		It("does not notify after unsubscribe", func() {
+			unsubscribe := subscribe()
+			unsubscribe()
+			Expect(logLevelManager.Set(ctx, glog.Level(3))).To(Succeed())
+			Expect(getChanges()).To(BeEmpty())
+			time.Sleep(100 * time.Millisecond)
+		})
+	})
+
+	Context("SetFor", func() {
+		var logLevelManager log.LogLevelManager
+
+		BeforeEach(func() {
+			logLevelManager = log.NewLogLevelSetter(
+				glog.Level(1),
+				time.Hour,
+				log.WithLogLevelFlagSet(flagSet),
+			)
+		})
+
+		It("resets after the given duration", func() {
+			Expect(logLevelManager.SetFor(ctx, glog.Level(3), 50*time.Millisecond)).To(Succeed())
+			Expect(logLevelManager.State().LogLevel).To(Equal(glog.Level(3)))
+			Expect(logLevelManager.State().AutoResetIn).To(BeNumerically("<=", 50*time.Millisecond))
+			Eventually(func() glog.Level {
+				return logLevelManager.State().LogLevel
+			}).Should(Equal(glog.Level(1)))
+		})
+
+		It("resets immediately", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(3))).To(Succeed())
+			Expect(logLevelManager.Reset(ctx)).To(Succeed())
+			state := logLevelManager.State()
+			Expect(state.LogLevel).To(Equal(glog.Level(1)))
+			Expect(state.AutoResetIn).To(BeZero())
+		})
+	})
+
+	Context("Elevate", func() {
+		var logLevelManager log.LogLevelManager
+
+		BeforeEach(func() {
+			logLevelManager = log.NewLogLevelSetter(
+				glog.Level(1),
+				time.Hour,
+				log.WithLogLevelFlagSet(flagSet),
+			)
+		})
+
+		currentLevel := func() string {
+			return strconv.Itoa(int(logLevelManager.State().LogLevel))
+		}
+
+		It("raises level until restore is called", func() {
+			restore := logLevelManager.Elevate(ctx, glog.Level(4), time.Hour)
+			Expect(currentLevel()).To(Equal("4"))
+			restore()
+			Expect(currentLevel()).To(Equal("1"))
+		})
+
+		It("drops back after duration", func() {
+			logLevelManager.Elevate(ctx, glog.Level(4), 50*time.Millisecond)
+			Expect(currentLevel()).To(Equal("4"))
+			Eventually(currentLevel).Should(Equal("1"))
+		})
+
+		It("ignores restore called twice", func() {
+			restore := logLevelManager.Elevate(ctx, glog.Level(4), time.Hour)
+			other := logLevelManager.Elevate(ctx, glog.Level(3), time.Hour)
+			restore()
+			restore()
+			Expect(currentLevel()).To(Equal("3"))
+			other()
+			Expect(currentLevel()).To(Equal("1"))
+		})
+
+		It("combines overlapping elevations with highest level winning", func() {
+			restoreLow := logLevelManager.Elevate(ctx, glog.Level(3), time.Hour)
+			restoreHigh := logLevelManager.Elevate(ctx, glog.Level(5), time.Hour)
+			Expect(currentLevel()).To(Equal("5"))
+
+			restoreHigh()
+			Expect(currentLevel()).To(Equal("3"))
+
+			restoreLow()
+			Expect(currentLevel()).To(Equal("1"))
+		})
+
+		It("keeps elevation if released in other order", func() {
+			restoreLow := logLevelManager.Elevate(ctx, glog.Level(3), time.Hour)
+			restoreHigh := logLevelManager.Elevate(ctx, glog.Level(5), time.Hour)
+
+			restoreLow()
+			Expect(currentLevel()).To(Equal("5"))
+
+			restoreHigh()
+			Expect(currentLevel()).To(Equal("1"))
+		})
+
+		It("keeps elevation if Set uses lower level", func() {
+			restore := logLevelManager.Elevate(ctx, glog.Level(4), time.Hour)
+			Expect(logLevelManager.Set(ctx, glog.Level(2))).To(Succeed())
+			Expect(currentLevel()).To(Equal("4"))
+
+			restore()
+			Expect(currentLevel()).To(Equal("2"))
+		})
+
+		It("drops back to level of Set", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(2))).To(Succeed())
+			restore := logLevelManager.Elevate(ctx, glog.Level(4), time.Hour)
+			Expect(currentLevel()).To(Equal("4"))
+
+			restore()
+			Expect(currentLevel()).To(Equal("2"))
+		})
+
+		It("drops back after concurrent elevations", func() {
+			const numGoroutines = 20
+			var wg sync.WaitGroup
+			for i := 0; i < numGoroutines; i++ {
+				wg.Add(1)
+				go func(level int) {
+					defer wg.Done()
+					restore := logLevelManager.Elevate(ctx, glog.Level(2+level%4), time.Hour)
+					time.Sleep(time.Millisecond)
+					restore()
+				}(i)
+			}
+			wg.Wait()
+			Expect(currentLevel()).To(Equal("1"))
+		})
+	})
+
+	Context("WithLogLevelStore", func() {
+		var logLevelManager log.LogLevelManager
+		var logLevelStore *mocks.LogLevelStore
+
+		BeforeEach(func() {
+			logLevelStore = &mocks.LogLevelStore{}
+			logLevelManager = log.NewLogLevelSetter(
+				glog.Level(1),
+				50*time.Millisecond,
+				log.WithLogLevelStore(logLevelStore),
+				log.WithLogLevelFlagSet(flagSet),
+			)
+		})
+
+		currentLevel := func() string {
+			return strconv.Itoa(int(logLevelManager.State().LogLevel))
+		}
+
+		It("saves override with expiry on Set", func() {
+			before := time.Now()
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			Expect(logLevelStore.SaveCallCount()).To(Equal(1))
+			_, override := logLevelStore.SaveArgsForCall(0)
+			Expect(override.LogLevel).To(Equal(glog.Level(4)))
+			Expect(override.Expiry).To(
+				BeTemporally("~", before.Add(50*time.Millisecond), time.Second),
+			)
+		})
+
+		It("deletes override on auto-reset", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			Eventually(logLevelStore.DeleteCallCount).Should(Equal(1))
+			Expect(currentLevel()).To(Equal("1"))
+		})
+
+		It("keeps level if save fails", func() {
+			logLevelStore.SaveReturns(errors.New("banana"))
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			Expect(currentLevel()).To(Equal("4"))
+		})
+
+		It("restores valid override until its expiry", func() {
+			logLevelStore.LoadReturns(&log.LogLevelOverride{
+				LogLevel: 3,
+				Expiry:   time.Now().Add(200 * time.Millisecond),
+			}, nil)
+			Expect(logLevelManager.Restore(ctx)).To(Succeed())
+			Expect(currentLevel()).To(Equal("3"))
+			Consistently(currentLevel, 100*time.Millisecond).Should(Equal("3"))
+			Eventually(currentLevel).Should(Equal("1"))
+		})
+
+		It("discards expired override", func() {
+			logLevelStore.LoadReturns(&log.LogLevelOverride{
+				LogLevel: 3,
+				Expiry:   time.Now().Add(-time.Minute),
+			}, nil)
+			Expect(logLevelManager.Restore(ctx)).To(Succeed())
+			Expect(currentLevel()).To(Equal("1"))
+			Expect(logLevelStore.DeleteCallCount()).To(Equal(1))
+		})
+
+		It("does nothing without stored override", func() {
+			Expect(logLevelManager.Restore(ctx)).To(Succeed())
+			Expect(currentLevel()).To(Equal("1"))
+		})
+
+		It("returns load error", func() {
+			logLevelStore.LoadReturns(nil, errors.New("banana"))
+			Expect(logLevelManager.Restore(ctx)).NotTo(Succeed())
+		})
+
+		It("deletes override on Set of the default level", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			Expect(logLevelManager.Set(ctx, glog.Level(1))).To(Succeed())
+			Expect(logLevelStore.SaveCallCount()).To(Equal(1))
+			Expect(logLevelStore.DeleteCallCount()).To(BeNumerically(">=", 1))
+		})
+
+		Context("with file store", func() {
+			var logLevelFileStore log.LogLevelStore
+
+			BeforeEach(func() {
+				logLevelFileStore = log.NewLogLevelFileStore(
+					filepath.Join(GinkgoT().TempDir(), "loglevel.json"),
+				)
+				logLevelManager = log.NewLogLevelSetter(
+					glog.Level(1),
+					time.Hour,
+					log.WithLogLevelStore(logLevelFileStore),
+					log.WithLogLevelFlagSet(flagSet),
+				)
+			})
+
+			It("leaves the store empty after Set of the default level", func() {
+				Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+				Expect(logLevelManager.Set(ctx, glog.Level(1))).To(Succeed())
+				override, err := logLevelFileStore.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(override).To(BeNil())
+			})
+
+			It("stores the active level of concurrent Sets", func() {
+				var wg sync.WaitGroup
+				for i := 0; i < 20; i++ {
+					wg.Add(1)
+					go func(level glog.Level) {
+						defer wg.Done()
+						_ = logLevelManager.Set(ctx, level)
+					}(glog.Level(i%5 + 2))
+				}
+				wg.Wait()
+				override, err := logLevelFileStore.Load(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(override).NotTo(BeNil())
+				Expect(override.LogLevel).To(Equal(logLevelManager.State().LogLevel))
+				Expect(logLevelManager.Reset(ctx)).To(Succeed())
+			})
+		})
+
+		It("does nothing on Restore without store", func() {
+			logLevelManager = log.NewLogLevelSetter(glog.Level(1), time.Minute)
+			Expect(logLevelManager.Restore(ctx)).To(Succeed())
+		})
+	})
+
+	Context("State", func() {
+		var logLevelManager log.LogLevelManager
+
+		BeforeEach(func() {
+			logLevelManager = log.NewLogLevelSetter(
+				glog.Level(1),
+				time.Hour,
+				log.WithLogLevelFlagSet(flagSet),
+			)
+		})
+
+		It("returns default state", func() {
+			state := logLevelManager.State()
+			Expect(state.LogLevel).To(Equal(glog.Level(1)))
+			Expect(state.DefaultLogLevel).To(Equal(glog.Level(1)))
+			Expect(state.AutoResetIn).To(BeZero())
+			Expect(state.Changes).To(BeEmpty())
+		})
+
+		It("returns time until auto-reset after Set", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			state := logLevelManager.State()
+			Expect(state.LogLevel).To(Equal(glog.Level(4)))
+			Expect(state.AutoResetIn).To(BeNumerically("~", time.Hour, time.Second))
+		})
+
+		It("counts changes by source", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(2))).To(Succeed())
+			httpCtx := log.WithLogLevelChangeSource(ctx, log.LogLevelChangeSourceHTTP)
+			Expect(logLevelManager.Set(httpCtx, glog.Level(3))).To(Succeed())
+			logLevelManager.Elevate(httpCtx, glog.Level(4), time.Hour)()
+			Expect(logLevelManager.State().Changes).To(Equal(map[log.LogLevelChangeSource]uint64{
+				log.LogLevelChangeSourceProgrammatic: 1,
+				log.LogLevelChangeSourceHTTP:         2,
+			}))
+		})
+	})
+
+	Context("WithLogLevelCurrentTimeGetter", func() {
+		var logLevelManager log.LogLevelManager
+		var logLevelStore *mocks.LogLevelStore
+		var currentTime libtime.CurrentTime
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+			currentTime = libtime.NewCurrentTime()
+			currentTime.SetNow(now)
+			logLevelStore = &mocks.LogLevelStore{}
+			logLevelManager = log.NewLogLevelSetter(
+				glog.Level(1),
+				time.Hour,
+				log.WithLogLevelStore(logLevelStore),
+				log.WithLogLevelFlagSet(flagSet),
+				log.WithLogLevelCurrentTimeGetter(currentTime),
+			)
+		})
+
+		It("computes expiry and auto-reset with the clock", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			_, override := logLevelStore.SaveArgsForCall(0)
+			Expect(override.Expiry).To(Equal(now.Add(time.Hour)))
+
+			currentTime.SetNow(now.Add(10 * time.Minute))
+			Expect(logLevelManager.State().AutoResetIn).To(Equal(50 * time.Minute))
+			Expect(logLevelManager.Reset(ctx)).To(Succeed())
+		})
+
+		It("discards override expired on the clock", func() {
+			currentTime.SetNow(time.Now().Add(2 * time.Hour))
+			logLevelStore.LoadReturns(&log.LogLevelOverride{
+				LogLevel: 3,
+				Expiry:   time.Now().Add(time.Hour),
+			}, nil)
+			Expect(logLevelManager.Restore(ctx)).To(Succeed())
+			Expect(logLevelManager.State().LogLevel).To(Equal(glog.Level(1)))
+			Expect(logLevelStore.DeleteCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("WithLogLevelFlagSet", func() {
+		var logLevelManager log.LogLevelManager
+		var originalLevel string
+
+		BeforeEach(func() {
+			originalLevel = flag.Lookup("v").Value.String()
+			logLevelManager = log.NewLogLevelSetter(
+				glog.Level(1),
+				50*time.Millisecond,
+				log.WithLogLevelFlagSet(flagSet),
+			)
+		})
+
+		currentLevel := func() string {
+			return strconv.Itoa(int(logLevelManager.State().LogLevel))
+		}
+
+		It("sets level only in the given FlagSet", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			Expect(currentLevel()).To(Equal("4"))
+			Expect(flag.Lookup("v").Value.String()).To(Equal(originalLevel))
+			Expect(logLevelManager.State().LogLevel).To(Equal(glog.Level(4)))
+		})
+
+		It("resets level in the given FlagSet", func() {
+			Expect(logLevelManager.Set(ctx, glog.Level(4))).To(Succeed())
+			Eventually(currentLevel).Should(Equal("1"))
+		})
+
+		It("elevates level in the given FlagSet", func() {
+			restore := logLevelManager.Elevate(ctx, glog.Level(3), time.Hour)
+			Expect(currentLevel()).To(Equal("3"))
+			restore()
+			Expect(currentLevel()).To(Equal("1"))
+		})
+
+		Context("without flag v", func() {
+			BeforeEach(func() {
+				logLevelManager = log.NewLogLevelSetter(
+					glog.Level(1),
+					50*time.Millisecond,
+					log.WithLogLevelFlagSet(flag.NewFlagSet("empty", flag.ContinueOnError)),
+				)
+			})
+
+			It("returns error on Set", func() {
+				Expect(logLevelManager.Set(ctx, glog.Level(4))).NotTo(Succeed())
+			})
+
+			It("does not count failed changes", func() {
+				_ = logLevelManager.Set(ctx, glog.Level(4))
+				state := logLevelManager.State()
+				Expect(state.Changes).To(BeEmpty())
+				Expect(state.AutoResetIn).To(BeZero())
+			})
+
+			It("ignores failed elevation", func() {
+				restore := logLevelManager.Elevate(ctx, glog.Level(4), time.Hour)
+				restore()
+				Expect(logLevelManager.State().Changes).To(BeEmpty())
+			})
+		})
+	})
+
 	Context("LogLevelSetterFunc", func() {
 		It("calls the wrapped function", func() {
 			called := false
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package log
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/golang/glog"
+)
+
+// LogLevelSignalHandler changes the log level on OS signals.
+// It is intended for batch jobs and CLIs without an HTTP server.
+type LogLevelSignalHandler interface {
+	// Run listens for signals until the context is cancelled.
+	Run(ctx context.Context) error
+}
+
+// NewLogLevelSignalHandler creates a LogLevelSignalHandler that listens for
+//
+//	SIGUSR1 - raise the log level one step above the current level of logLevelManager
+//	SIGUSR2 - reset the log level of logLevelManager to its default
+//
+// All changes are routed through the given LogLevelManager, so the auto-reset of
+// NewLogLevelSetter still applies.
+//
+// Example:
+//
+//	logLevelSetter := log.NewLogLevelSetter(glog.Level(1), 5*time.Minute)
+//	go log.NewLogLevelSignalHandler(logLevelSetter).Run(ctx)
+//
+// Change log level of a running process:
+//
+//	kill -USR1 <pid>  # raise verbosity by one
+//	kill -USR2 <pid>  # reset verbosity to default
+func NewLogLevelSignalHandler(logLevelManager LogLevelManager) LogLevelSignalHandler {
+	return &logLevelSignalHandler{
+		logLevelManager: logLevelManager,
+	}
+}
+
+type logLevelSignalHandler struct {
+	logLevelManager LogLevelManager
+}
+
+func (l *logLevelSignalHandler) Run(ctx context.Context) error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1, syscall.SIGUSR2)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			glog.V(2).Infof("log level signal handler stopped")
+			return nil
+		case sig := <-signals:
+			l.handleSignal(WithLogLevelChangeSource(ctx, LogLevelChangeSourceSignal), sig)
+		}
+	}
+}
+
+func (l *logLevelSignalHandler) handleSignal(ctx context.Context, sig os.Signal) {
+	switch sig {
+	case syscall.SIGUSR1:
+		logLevel := l.logLevelManager.State().LogLevel + 1
+		glog.V(2).Infof("received signal %v => set loglevel to %d", sig, logLevel)
+		if err := l.logLevelManager.Set(ctx, logLevel); err != nil {
+			glog.Warningf("set loglevel to %d after signal %v failed: %v", logLevel, sig, err)
+		}
+	case syscall.SIGUSR2:
+		glog.V(2).Infof("received signal %v => reset loglevel", sig)
+		if err := l.logLevelManager.Reset(ctx); err != nil {
+			glog.Warningf("reset loglevel after signal %v failed: %v", sig, err)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build unix
+
+package log_test
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log LogLevelSignalHandler", Serial, func() {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var mockLogLevelManager *mocks.LogLevelManager
+	var runErr chan error
+	var guard chan os.Signal
+
+	BeforeEach(func() {
+		// keep the default action (terminate) away from the test process
+		// until the handler has registered itself
+		guard = make(chan os.Signal, 10)
+		signal.Notify(guard, syscall.SIGUSR1, syscall.SIGUSR2)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		mockLogLevelManager = &mocks.LogLevelManager{}
+		mockLogLevelManager.StateReturns(log.LogLevelState{LogLevel: 2})
+		runErr = make(chan error, 1)
+		go func() {
+			runErr <- log.NewLogLevelSignalHandler(mockLogLevelManager).Run(ctx)
+		}()
+	})
+
+	AfterEach(func() {
+		cancel()
+		Eventually(runErr).Should(Receive(BeNil()))
+		signal.Stop(guard)
+	})
+
+	sendUntilCalled := func(sig syscall.Signal, callCount func() int) {
+		Eventually(func() int {
+			_ = syscall.Kill(os.Getpid(), sig)
+			return callCount()
+		}, time.Second, 20*time.Millisecond).Should(BeNumerically(">=", 1))
+	}
+
+	It("raises level one step on SIGUSR1", func() {
+		sendUntilCalled(syscall.SIGUSR1, mockLogLevelManager.SetCallCount)
+		actualCtx, level := mockLogLevelManager.SetArgsForCall(0)
+		Expect(level).To(Equal(glog.Level(3)))
+		Expect(log.LogLevelChangeSourceFromContext(actualCtx)).
+			To(Equal(log.LogLevelChangeSourceSignal))
+	})
+
+	It("resets level to default on SIGUSR2", func() {
+		sendUntilCalled(syscall.SIGUSR2, mockLogLevelManager.ResetCallCount)
+		Expect(log.LogLevelChangeSourceFromContext(mockLogLevelManager.ResetArgsForCall(0))).
+			To(Equal(log.LogLevelChangeSourceSignal))
+		Expect(mockLogLevelManager.SetCallCount()).To(Equal(0))
+	})
+
+	It("stops when context is cancelled", func() {
+		cancel()
+		Eventually(runErr).Should(Receive(BeNil()))
+		runErr <- nil
+	})
+})
@@ -0,0 +1,40 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+)
+
+// LogLevelChangeSource describes what triggered a log level change.
+type LogLevelChangeSource string
+
+const (
+	// LogLevelChangeSourceHTTP is used by the log level HTTP handlers.
+	LogLevelChangeSourceHTTP LogLevelChangeSource = "http"
+	// LogLevelChangeSourceSignal is used by NewLogLevelSignalHandler.
+	LogLevelChangeSourceSignal LogLevelChangeSource = "signal"
+	// LogLevelChangeSourceFile is used by NewLogLevelFileWatcher.
+	LogLevelChangeSourceFile LogLevelChangeSource = "file"
+	// LogLevelChangeSourceProgrammatic is the default for changes without source.
+	LogLevelChangeSourceProgrammatic LogLevelChangeSource = "programmatic"
+)
+
+type logLevelChangeSourceContextKey struct{}
+
+// WithLogLevelChangeSource returns a copy of ctx that tags log level changes made with it
+// with the given source.
+func WithLogLevelChangeSource(ctx context.Context, source LogLevelChangeSource) context.Context {
+	return context.WithValue(ctx, logLevelChangeSourceContextKey{}, source)
+}
+
+// LogLevelChangeSourceFromContext returns the source stored by WithLogLevelChangeSource
+// or LogLevelChangeSourceProgrammatic if none is stored.
+func LogLevelChangeSourceFromContext(ctx context.Context) LogLevelChangeSource {
+	if source, ok := ctx.Value(logLevelChangeSourceContextKey{}).(LogLevelChangeSource); ok {
+		return source
+	}
+	return LogLevelChangeSourceProgrammatic
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bborbe/errors"
+	"github.com/golang/glog"
+)
+
+// LogLevelOverride is a log level set at runtime together with the absolute time
+// it expires and the level is reset to the default.
+type LogLevelOverride struct {
+	LogLevel glog.Level `json:"loglevel"`
+	Expiry   time.Time  `json:"expiry"`
+}
+
+//counterfeiter:generate -o mocks/log-loglevel-store.go --fake-name LogLevelStore . LogLevelStore
+
+// LogLevelStore persists the active log level override, so it survives restarts.
+type LogLevelStore interface {
+	// Save stores the override and replaces any previous one.
+	Save(ctx context.Context, override LogLevelOverride) error
+	// Load returns the stored override or nil if none is stored.
+	Load(ctx context.Context) (*LogLevelOverride, error)
+	// Delete removes the stored override. Deleting a missing override is not an error.
+	Delete(ctx context.Context) error
+}
+
+// NewLogLevelFileStore returns a LogLevelStore that keeps the override as JSON in the given file.
+// The file is replaced atomically by writing a temporary file in the same directory and
+// renaming it, so concurrent writers and crashes never leave a partially written file.
+//
+// Use a path on a volume that survives pod restarts, e.g. an emptyDir:
+//
+//	logLevelSetter := log.NewLogLevelSetter(
+//	    glog.Level(1),
+//	    5*time.Minute,
+//	    log.WithLogLevelStore(log.NewLogLevelFileStore("/var/run/app/loglevel.json")),
+//	)
+//	if err := logLevelSetter.Restore(ctx); err != nil {
+//	    return err
+//	}
+func NewLogLevelFileStore(path string) LogLevelStore {
+	return &logLevelFileStore{
+		path: path,
+	}
+}
+
+type logLevelFileStore struct {
+	path string
+	mux  sync.Mutex
+}
+
+func (l *logLevelFileStore) Save(ctx context.Context, override LogLevelOverride) error {
+	content, err := json.Marshal(override)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "marshal override failed")
+	}
+
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	dir, name := filepath.Split(l.path)
+	if dir == "" {
+		dir = "."
+	}
+	file, err := os.CreateTemp(dir, "."+name+".tmp*")
+	if err != nil {
+		return errors.Wrapf(ctx, err, "create temp file in '%s' failed", dir)
+	}
+	tmpPath := file.Name()
+	defer os.Remove(tmpPath) // #nosec G104 -- no-op after successful rename
+
+	if _, err := file.Write(content); err != nil {
+		_ = file.Close()
+		return errors.Wrapf(ctx, err, "write '%s' failed", tmpPath)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return errors.Wrapf(ctx, err, "sync '%s' failed", tmpPath)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(ctx, err, "close '%s' failed", tmpPath)
+	}
+	if err := os.Rename(tmpPath, l.path); err != nil {
+		return errors.Wrapf(ctx, err, "rename '%s' to '%s' failed", tmpPath, l.path)
+	}
+	return nil
+}
+
+func (l *logLevelFileStore) Load(ctx context.Context) (*LogLevelOverride, error) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	content, err := os.ReadFile(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(ctx, err, "read '%s' failed", l.path)
+	}
+	var override LogLevelOverride
+	if err := json.Unmarshal(content, &override); err != nil {
+		return nil, errors.Wrapf(ctx, err, "unmarshal '%s' failed", l.path)
+	}
+	return &override, nil
+}
+
+func (l *logLevelFileStore) Delete(ctx context.Context) error {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(ctx, err, "remove '%s' failed", l.path)
+	}
+	return nil
+}
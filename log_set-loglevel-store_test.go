@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+)
+
+var _ = Describe("Log LogLevelFileStore", func() {
+	var ctx context.Context
+	var dir string
+	var path string
+	var logLevelStore log.LogLevelStore
+	var override log.LogLevelOverride
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		dir = GinkgoT().TempDir()
+		path = filepath.Join(dir, "loglevel.json")
+		logLevelStore = log.NewLogLevelFileStore(path)
+		override = log.LogLevelOverride{
+			LogLevel: 4,
+			Expiry:   time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC),
+		}
+	})
+
+	It("returns nil if nothing is stored", func() {
+		result, err := logLevelStore.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("loads saved override", func() {
+		Expect(logLevelStore.Save(ctx, override)).To(Succeed())
+		result, err := logLevelStore.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.LogLevel).To(Equal(glog.Level(4)))
+		Expect(result.Expiry).To(BeTemporally("==", override.Expiry))
+	})
+
+	It("loads override saved by another store instance", func() {
+		Expect(logLevelStore.Save(ctx, override)).To(Succeed())
+		result, err := log.NewLogLevelFileStore(path).Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		Expect(result.LogLevel).To(Equal(glog.Level(4)))
+	})
+
+	It("deletes override", func() {
+		Expect(logLevelStore.Save(ctx, override)).To(Succeed())
+		Expect(logLevelStore.Delete(ctx)).To(Succeed())
+		result, err := logLevelStore.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(BeNil())
+	})
+
+	It("ignores delete of missing override", func() {
+		Expect(logLevelStore.Delete(ctx)).To(Succeed())
+	})
+
+	It("returns error for corrupt file", func() {
+		Expect(os.WriteFile(path, []byte("banana"), 0600)).To(Succeed())
+		_, err := logLevelStore.Load(ctx)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("survives concurrent writers without leaving temp files", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(level int) {
+				defer wg.Done()
+				store := log.NewLogLevelFileStore(path)
+				Expect(store.Save(ctx, log.LogLevelOverride{
+					LogLevel: glog.Level(level),
+					Expiry:   override.Expiry,
+				})).To(Succeed())
+			}(i)
+		}
+		wg.Wait()
+
+		result, err := logLevelStore.Load(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).NotTo(BeNil())
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/bborbe/errors"
+	"github.com/gorilla/mux"
+)
+
+// settingOverrideJSON is the JSON representation of a SettingOverride.
+type settingOverrideJSON struct {
+	Name    string     `json:"name"`
+	Value   string     `json:"value"`
+	Default string     `json:"default"`
+	ResetAt *time.Time `json:"resetAt,omitempty"`
+}
+
+func newSettingOverrideJSONs(overrides []SettingOverride) []settingOverrideJSON {
+	result := make([]settingOverrideJSON, 0, len(overrides))
+	for _, override := range overrides {
+		entry := settingOverrideJSON{
+			Name:    override.Name,
+			Value:   override.Value,
+			Default: override.Default,
+		}
+		if !override.ResetAt.IsZero() {
+			resetAt := override.ResetAt
+			entry.ResetAt = &resetAt
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// NewSettingOverrideHandler creates an HTTP handler to manage a SettingOverrider:
+//
+//	GET    - list all settings with current value, default and reset deadline as JSON
+//	POST   - override setting "name" with query parameter "value" for the optional "duration"
+//	DELETE - reset setting "name" to its default
+//
+// The name is read from the path variable "name" or the query parameter "name".
+// POST and DELETE respond with the list of all settings.
+//
+// Example requests:
+//
+//	POST   /debug/settings/blockprofilerate?value=1&duration=10m
+//	DELETE /debug/settings/blockprofilerate
+func NewSettingOverrideHandler(settingOverrider SettingOverrider) http.Handler {
+	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		switch req.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPost:
+			duration, err := parseOptionalDuration(ctx, req.URL.Query().Get("duration"))
+			if err != nil {
+				http.Error(resp, err.Error(), http.StatusBadRequest)
+				return
+			}
+			name := requestVar(req, "name")
+			value := req.URL.Query().Get("value")
+			if err := settingOverrider.Override(ctx, name, value, duration); err != nil {
+				writeSettingOverrideError(resp, err)
+				return
+			}
+		case http.MethodDelete:
+			if err := settingOverrider.Reset(ctx, requestVar(req, "name")); err != nil {
+				writeSettingOverrideError(resp, err)
+				return
+			}
+		default:
+			resp.Header().Set("Allow", "GET, HEAD, POST, DELETE")
+			http.Error(resp, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(resp, req, http.StatusOK, newSettingOverrideJSONs(settingOverrider.List(ctx)))
+	})
+}
+
+// NewSettingOverrideAdminRoutes returns the routes to manage overridable settings:
+//
+//	GET    {prefix}/settings                                - list settings
+//	POST   {prefix}/settings/{name}?value=..&duration=..  - override setting
+//	DELETE {prefix}/settings/{name}                         - reset setting
+func NewSettingOverrideAdminRoutes(settingOverrider SettingOverrider) AdminRoutes {
+	handler := NewSettingOverrideHandler(settingOverrider)
+	return AdminRoutes{
+		{Pattern: "/settings/{name}", Handler: handler},
+		{Pattern: "/settings", Handler: handler},
+	}
+}
+
+func writeSettingOverrideError(resp http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, ErrSettingNotFound) {
+		status = http.StatusNotFound
+	}
+	http.Error(resp, err.Error(), status)
+}
+
+// requestVar returns the path value of http.ServeMux, the gorilla/mux var or the
+// query parameter with the given name, whichever is set first.
+func requestVar(req *http.Request, name string) string {
+	if value := req.PathValue(name); value != "" {
+		return value
+	}
+	if value := mux.Vars(req)[name]; value != "" {
+		return value
+	}
+	return req.URL.Query().Get(name)
+}
+
+// parseOptionalDuration parses a Go duration string, an empty string returns zero.
+func parseOptionalDuration(ctx context.Context, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.Wrapf(ctx, err, "parse duration '%s' failed", value)
+	}
+	return duration, nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bborbe/errors"
+	libtime "github.com/bborbe/time"
+	"github.com/golang/glog"
+)
+
+// ErrSettingNotFound is returned by SettingOverrider for unknown setting names.
+var ErrSettingNotFound = stderrors.New("setting not found")
+
+// SettingOverride describes the state of an OverridableSetting managed by a SettingOverrider.
+type SettingOverride struct {
+	Name    string
+	Value   string
+	Default string
+	// ResetAt is the time the override is reset to Default, zero if not overridden.
+	ResetAt time.Time
+}
+
+//counterfeiter:generate -o mocks/log-setting-overrider.go --fake-name SettingOverrider . SettingOverrider
+
+// SettingOverrider temporarily overrides settings and resets them to their default
+// after a duration. NewLogLevelSetter uses it for the auto-reset of the glog verbosity.
+type SettingOverrider interface {
+	// Override sets the setting to value and resets it after duration.
+	// A duration <= 0 uses the default duration of the SettingOverrider.
+	// Overriding an overridden setting replaces value and deadline.
+	Override(ctx context.Context, name string, value string, duration time.Duration) error
+	// Reset sets the setting back to its default immediately.
+	Reset(ctx context.Context, name string) error
+	// List returns all settings sorted by name.
+	List(ctx context.Context) []SettingOverride
+}
+
+// NewSettingOverrider creates a SettingOverrider for the given settings.
+// The default of every setting is its value at construction time.
+//
+// Example:
+//
+//	stderrthreshold, err := log.NewFlagSetting(ctx, flag.CommandLine, "stderrthreshold")
+//	if err != nil {
+//	    return err
+//	}
+//	settingOverrider := log.NewSettingOverrider(
+//	    libtime.NewCurrentTime(),
+//	    5*time.Minute,
+//	    stderrthreshold,
+//	    log.NewMemProfileRateSetting(),
+//	    log.NewBlockProfileRateSetting(),
+//	    log.NewMutexProfileFractionSetting(),
+//	)
+//	err = settingOverrider.Override(ctx, "blockprofilerate", "1", 10*time.Minute)
+func NewSettingOverrider(
+	currentTimeGetter libtime.CurrentTimeGetter,
+	defaultDuration time.Duration,
+	settings ...OverridableSetting,
+) SettingOverrider {
+	overrides := make(map[string]*settingOverride, len(settings))
+	for _, setting := range settings {
+		overrides[setting.Name()] = &settingOverride{
+			setting:      setting,
+			defaultValue: setting.Value(),
+		}
+	}
+	return &settingOverrider{
+		currentTimeGetter: currentTimeGetter,
+		defaultDuration:   defaultDuration,
+		overrides:         overrides,
+	}
+}
+
+type settingOverrider struct {
+	currentTimeGetter libtime.CurrentTimeGetter
+	defaultDuration   time.Duration
+
+	mux       sync.Mutex
+	overrides map[string]*settingOverride
+}
+
+type settingOverride struct {
+	setting      OverridableSetting
+	defaultValue string
+	resetAt      time.Time
+	timer        *deadlineTimer
+	// generation invalidates resets scheduled by earlier overrides
+	generation uint64
+}
+
+func (s *settingOverrider) Override(
+	ctx context.Context,
+	name string,
+	value string,
+	duration time.Duration,
+) error {
+	if duration <= 0 {
+		duration = s.defaultDuration
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	override, ok := s.overrides[name]
+	if !ok {
+		return errors.Wrapf(ctx, ErrSettingNotFound, "override '%s' failed", name)
+	}
+	previous := override.setting.Value()
+	if err := override.setting.Set(ctx, value); err != nil {
+		// flag values may be modified even if parsing fails
+		if restoreErr := override.setting.Set(ctx, previous); restoreErr != nil {
+			glog.Warningf("restore %s to '%s' failed: %v", name, previous, restoreErr)
+		}
+		return errors.Wrapf(ctx, err, "override '%s' failed", name)
+	}
+	s.stopLocked(override)
+	override.resetAt = s.currentTimeGetter.Now().Add(duration)
+	generation := override.generation
+	override.timer = newDeadlineTimer(s.currentTimeGetter, override.resetAt, func() {
+		s.reset(name, generation)
+	})
+	glog.V(2).Infof(
+		"override %s to '%s' and reset in %v back to '%s'",
+		name,
+		value,
+		duration,
+		override.defaultValue,
+	)
+	return nil
+}
+
+func (s *settingOverrider) Reset(ctx context.Context, name string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	override, ok := s.overrides[name]
+	if !ok {
+		return errors.Wrapf(ctx, ErrSettingNotFound, "reset '%s' failed", name)
+	}
+	s.stopLocked(override)
+	if err := s.resetLocked(ctx, override); err != nil {
+		return errors.Wrapf(ctx, err, "reset '%s' failed", name)
+	}
+	return nil
+}
+
+func (s *settingOverrider) List(ctx context.Context) []SettingOverride {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := make([]SettingOverride, 0, len(s.overrides))
+	for name, override := range s.overrides {
+		result = append(result, SettingOverride{
+			Name:    name,
+			Value:   override.setting.Value(),
+			Default: override.defaultValue,
+			ResetAt: override.resetAt,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// reset is called by the timer of an override.
+func (s *settingOverrider) reset(name string, generation uint64) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	override := s.overrides[name]
+	if override.generation != generation {
+		// overridden again or reset in the meantime
+		return
+	}
+	override.generation++
+	override.timer = nil
+	if err := s.resetLocked(context.Background(), override); err != nil {
+		glog.Warningf("reset %s failed: %v", name, err)
+	}
+}
+
+// stopLocked cancels the pending reset of the override.
+// The caller must hold the mutex.
+func (s *settingOverrider) stopLocked(override *settingOverride) {
+	override.generation++
+	if override.timer != nil {
+		override.timer.Stop()
+		override.timer = nil
+	}
+}
+
+// resetLocked sets the setting back to its default.
+// The caller must hold the mutex.
+func (s *settingOverrider) resetLocked(ctx context.Context, override *settingOverride) error {
+	override.resetAt = time.Time{}
+	if err := override.setting.Set(ctx, override.defaultValue); err != nil {
+		return err
+	}
+	glog.V(2).Infof("%s set back to '%s'", override.setting.Name(), override.defaultValue)
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"time"
+
+	libtime "github.com/bborbe/time"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log SettingOverrider", func() {
+	var ctx context.Context
+	var now time.Time
+	var currentTime libtime.CurrentTime
+	var flagSet *flag.FlagSet
+	var settingOverrider log.SettingOverrider
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 10, 18, 2, 0, 0, 0, time.UTC)
+		currentTime = libtime.NewCurrentTime()
+		currentTime.SetNow(now)
+		flagSet = flag.NewFlagSet("test", flag.ContinueOnError)
+		flagSet.String("feature", "off", "feature flag")
+		flagSet.Int("threshold", 2, "threshold")
+		feature, err := log.NewFlagSetting(ctx, flagSet, "feature")
+		Expect(err).NotTo(HaveOccurred())
+		threshold, err := log.NewFlagSetting(ctx, flagSet, "threshold")
+		Expect(err).NotTo(HaveOccurred())
+		settingOverrider = log.NewSettingOverrider(
+			currentTime,
+			time.Hour,
+			feature,
+			threshold,
+		)
+	})
+
+	value := func(name string) func() string {
+		return func() string {
+			// read through List, which is synchronized with the resets
+			for _, override := range settingOverrider.List(ctx) {
+				if override.Name == name {
+					return override.Value
+				}
+			}
+			return ""
+		}
+	}
+
+	It("returns error for unknown flag", func() {
+		_, err := log.NewFlagSetting(ctx, flagSet, "banana")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("lists settings with defaults", func() {
+		Expect(settingOverrider.List(ctx)).To(Equal([]log.SettingOverride{
+			{Name: "feature", Value: "off", Default: "off"},
+			{Name: "threshold", Value: "2", Default: "2"},
+		}))
+	})
+
+	It("overrides until duration expired", func() {
+		Expect(settingOverrider.Override(ctx, "feature", "on", 50*time.Millisecond)).To(Succeed())
+		Expect(value("feature")()).To(Equal("on"))
+		Expect(settingOverrider.List(ctx)[0].ResetAt).To(Equal(now.Add(50 * time.Millisecond)))
+		Consistently(value("feature"), 100*time.Millisecond).Should(Equal("on"))
+		currentTime.SetNow(now.Add(50 * time.Millisecond))
+		Eventually(value("feature")).Should(Equal("off"))
+		Eventually(func() time.Time {
+			return settingOverrider.List(ctx)[0].ResetAt
+		}).Should(BeZero())
+	})
+
+	It("uses default duration", func() {
+		Expect(settingOverrider.Override(ctx, "feature", "on", 0)).To(Succeed())
+		Expect(settingOverrider.List(ctx)[0].ResetAt).To(Equal(now.Add(time.Hour)))
+		Expect(settingOverrider.Reset(ctx, "feature")).To(Succeed())
+	})
+
+	It("extends override when overridden again", func() {
+		Expect(settingOverrider.Override(ctx, "feature", "on", 50*time.Millisecond)).To(Succeed())
+		Expect(settingOverrider.Override(ctx, "feature", "more", time.Hour)).To(Succeed())
+		Consistently(value("feature"), 100*time.Millisecond).Should(Equal("more"))
+		Expect(settingOverrider.Reset(ctx, "feature")).To(Succeed())
+	})
+
+	It("resets immediately", func() {
+		Expect(settingOverrider.Override(ctx, "threshold", "5", time.Hour)).To(Succeed())
+		Expect(settingOverrider.Reset(ctx, "threshold")).To(Succeed())
+		Expect(value("threshold")()).To(Equal("2"))
+		Expect(settingOverrider.List(ctx)[1].ResetAt).To(BeZero())
+	})
+
+	It("returns error for invalid value and keeps state", func() {
+		Expect(settingOverrider.Override(ctx, "threshold", "banana", time.Hour)).NotTo(Succeed())
+		Expect(value("threshold")()).To(Equal("2"))
+		Expect(settingOverrider.List(ctx)[1].ResetAt).To(BeZero())
+	})
+
+	It("returns ErrSettingNotFound", func() {
+		err := settingOverrider.Override(ctx, "banana", "1", time.Hour)
+		Expect(errors.Is(err, log.ErrSettingNotFound)).To(BeTrue())
+		err = settingOverrider.Reset(ctx, "banana")
+		Expect(errors.Is(err, log.ErrSettingNotFound)).To(BeTrue())
+	})
+
+	Context("runtime settings", func() {
+		It("changes MemProfileRate", func() {
+			original := runtime.MemProfileRate
+			defer func() { runtime.MemProfileRate = original }()
+
+			setting := log.NewMemProfileRateSetting()
+			Expect(setting.Name()).To(Equal("memprofilerate"))
+			Expect(setting.Set(ctx, "1")).To(Succeed())
+			Expect(runtime.MemProfileRate).To(Equal(1))
+			Expect(setting.Value()).To(Equal("1"))
+		})
+		It("changes block profile rate", func() {
+			setting := log.NewBlockProfileRateSetting()
+			Expect(setting.Value()).To(Equal("0"))
+			Expect(setting.Set(ctx, "1")).To(Succeed())
+			Expect(setting.Value()).To(Equal("1"))
+			Expect(setting.Set(ctx, "0")).To(Succeed())
+		})
+		It("changes mutex profile fraction", func() {
+			setting := log.NewMutexProfileFractionSetting()
+			original := setting.Value()
+			Expect(setting.Set(ctx, "5")).To(Succeed())
+			Expect(setting.Value()).To(Equal("5"))
+			Expect(setting.Set(ctx, original)).To(Succeed())
+		})
+		It("rejects negative rates", func() {
+			Expect(log.NewMutexProfileFractionSetting().Set(ctx, "-1")).NotTo(Succeed())
+			Expect(log.NewBlockProfileRateSetting().Set(ctx, "banana")).NotTo(Succeed())
+		})
+	})
+})
+
+var _ = Describe("Log SettingOverrideHandler", func() {
+	var settingOverrider *mocks.SettingOverrider
+	var serveMux *http.ServeMux
+	var recorder *httptest.ResponseRecorder
+
+	BeforeEach(func() {
+		settingOverrider = &mocks.SettingOverrider{}
+		settingOverrider.ListReturns([]log.SettingOverride{
+			{
+				Name:    "feature",
+				Value:   "on",
+				Default: "off",
+				ResetAt: time.Date(2026, 10, 18, 2, 30, 0, 0, time.UTC),
+			},
+			{Name: "threshold", Value: "2", Default: "2"},
+		})
+		serveMux = http.NewServeMux()
+		log.RegisterAdminRoutes(
+			serveMux,
+			"/debug",
+			log.NewAuthorizerAllowAll(),
+			log.NewSettingOverrideAdminRoutes(settingOverrider),
+		)
+		recorder = httptest.NewRecorder()
+	})
+
+	serve := func(method string, target string) {
+		serveMux.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+	}
+
+	It("lists settings", func() {
+		serve(http.MethodGet, "/debug/settings")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(MatchJSON(`[
+			{"name":"feature","value":"on","default":"off","resetAt":"2026-10-18T02:30:00Z"},
+			{"name":"threshold","value":"2","default":"2"}
+		]`))
+	})
+
+	It("overrides setting", func() {
+		serve(http.MethodPost, "/debug/settings/feature?value=on&duration=10m")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(settingOverrider.OverrideCallCount()).To(Equal(1))
+		_, name, value, duration := settingOverrider.OverrideArgsForCall(0)
+		Expect(name).To(Equal("feature"))
+		Expect(value).To(Equal("on"))
+		Expect(duration).To(Equal(10 * time.Minute))
+	})
+
+	It("rejects invalid duration", func() {
+		serve(http.MethodPost, "/debug/settings/feature?value=on&duration=banana")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		Expect(settingOverrider.OverrideCallCount()).To(Equal(0))
+	})
+
+	It("returns not found for unknown setting", func() {
+		settingOverrider.OverrideReturns(log.ErrSettingNotFound)
+		serve(http.MethodPost, "/debug/settings/banana?value=1")
+		Expect(recorder.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("returns bad request for invalid value", func() {
+		settingOverrider.OverrideReturns(errors.New("invalid"))
+		serve(http.MethodPost, "/debug/settings/threshold?value=banana")
+		Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("resets setting", func() {
+		serve(http.MethodDelete, "/debug/settings/feature")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		_, name := settingOverrider.ResetArgsForCall(0)
+		Expect(name).To(Equal("feature"))
+	})
+
+	It("rejects other methods", func() {
+		serve(http.MethodPut, "/debug/settings/feature")
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
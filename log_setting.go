@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"context"
+	"flag"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/bborbe/errors"
+)
+
+//counterfeiter:generate -o mocks/log-overridable-setting.go --fake-name OverridableSetting . OverridableSetting
+
+// OverridableSetting is a named runtime setting that can be read and changed as string,
+// e.g. a flag or a runtime profiling rate.
+type OverridableSetting interface {
+	// Name returns the unique name of the setting.
+	Name() string
+	// Value returns the current value.
+	Value() string
+	// Set changes the value.
+	Set(ctx context.Context, value string) error
+}
+
+// NewFlagSetting returns an OverridableSetting for the flag with the given name in flagSet,
+// e.g. glog's "stderrthreshold" or "logtostderr" in flag.CommandLine or own feature flags.
+// It returns an error if the flag does not exist.
+func NewFlagSetting(
+	ctx context.Context,
+	flagSet *flag.FlagSet,
+	name string,
+) (OverridableSetting, error) {
+	if flagSet.Lookup(name) == nil {
+		return nil, errors.Errorf(ctx, "flag '%s' not found", name)
+	}
+	return NewFuncSetting(
+		name,
+		func() string {
+			return flagSet.Lookup(name).Value.String()
+		},
+		func(ctx context.Context, value string) error {
+			if err := flagSet.Set(name, value); err != nil {
+				return errors.Wrapf(ctx, err, "set flag '%s' to '%s' failed", name, value)
+			}
+			return nil
+		},
+	), nil
+}
+
+// NewFuncSetting returns an OverridableSetting backed by getter and setter functions.
+//
+// Example:
+//
+//	setting := log.NewFuncSetting(
+//	    "feature-x",
+//	    func() string { return strconv.FormatBool(featureX.Load()) },
+//	    func(ctx context.Context, value string) error {
+//	        enabled, err := strconv.ParseBool(value)
+//	        if err != nil {
+//	            return errors.Wrapf(ctx, err, "parse bool failed")
+//	        }
+//	        featureX.Store(enabled)
+//	        return nil
+//	    },
+//	)
+func NewFuncSetting(
+	name string,
+	getter func() string,
+	setter func(ctx context.Context, value string) error,
+) OverridableSetting {
+	return &funcSetting{
+		name:   name,
+		getter: getter,
+		setter: setter,
+	}
+}
+
+type funcSetting struct {
+	name   string
+	getter func() string
+	setter func(ctx context.Context, value string) error
+}
+
+func (f *funcSetting) Name() string {
+	return f.name
+}
+
+func (f *funcSetting) Value() string {
+	return f.getter()
+}
+
+func (f *funcSetting) Set(ctx context.Context, value string) error {
+	return f.setter(ctx, value)
+}
+
+// NewMemProfileRateSetting returns an OverridableSetting named "memprofilerate"
+// for runtime.MemProfileRate. Changes are not synchronized with allocations in progress,
+// which is acceptable for temporarily sampling more or fewer allocations.
+func NewMemProfileRateSetting() OverridableSetting {
+	return NewFuncSetting(
+		"memprofilerate",
+		func() string {
+			return strconv.Itoa(runtime.MemProfileRate)
+		},
+		func(ctx context.Context, value string) error {
+			rate, err := parseRate(ctx, value)
+			if err != nil {
+				return err
+			}
+			runtime.MemProfileRate = rate
+			return nil
+		},
+	)
+}
+
+// NewBlockProfileRateSetting returns an OverridableSetting named "blockprofilerate"
+// for runtime.SetBlockProfileRate. The runtime has no getter, so the value is the last one
+// set through this setting, starting with 0 (disabled).
+func NewBlockProfileRateSetting() OverridableSetting {
+	var mux sync.Mutex
+	var current int
+	return NewFuncSetting(
+		"blockprofilerate",
+		func() string {
+			mux.Lock()
+			defer mux.Unlock()
+			return strconv.Itoa(current)
+		},
+		func(ctx context.Context, value string) error {
+			rate, err := parseRate(ctx, value)
+			if err != nil {
+				return err
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			runtime.SetBlockProfileRate(rate)
+			current = rate
+			return nil
+		},
+	)
+}
+
+// NewMutexProfileFractionSetting returns an OverridableSetting named "mutexprofilefraction"
+// for runtime.SetMutexProfileFraction.
+func NewMutexProfileFractionSetting() OverridableSetting {
+	return NewFuncSetting(
+		"mutexprofilefraction",
+		func() string {
+			// a negative rate only reads the current fraction
+			return strconv.Itoa(runtime.SetMutexProfileFraction(-1))
+		},
+		func(ctx context.Context, value string) error {
+			rate, err := parseRate(ctx, value)
+			if err != nil {
+				return err
+			}
+			runtime.SetMutexProfileFraction(rate)
+			return nil
+		},
+	)
+}
+
+// parseRate parses a non-negative integer rate.
+func parseRate(ctx context.Context, value string) (int, error) {
+	rate, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Wrapf(ctx, err, "parse rate '%s' failed", value)
+	}
+	if rate < 0 {
+		return 0, errors.Errorf(ctx, "rate %d must not be negative", rate)
+	}
+	return rate, nil
+}
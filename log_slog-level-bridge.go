@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log
+
+import (
+	"log/slog"
+
+	"github.com/golang/glog"
+)
+
+// SlogLevelMapping maps glog verbosity levels to slog levels.
+// A glog level uses the entry with the highest key that is less or equal to it.
+// Levels below the lowest key use the entry of the lowest key.
+type SlogLevelMapping map[glog.Level]slog.Level
+
+// DefaultSlogLevelMapping returns a new mapping of
+//
+//	v=0..1 to slog.LevelInfo
+//	v=2..3 to slog.LevelDebug
+//	v>=4   to slog.LevelDebug-4 (trace)
+func DefaultSlogLevelMapping() SlogLevelMapping {
+	return SlogLevelMapping{
+		0: slog.LevelInfo,
+		2: slog.LevelDebug,
+		4: slog.LevelDebug - 4,
+	}
+}
+
+// SlogLevel returns the slog level for the given glog verbosity.
+// An empty mapping returns slog.LevelInfo.
+func (s SlogLevelMapping) SlogLevel(logLevel glog.Level) slog.Level {
+	var found, lowestFound bool
+	var foundKey, lowestKey glog.Level
+	for key := range s {
+		if key <= logLevel && (!found || key > foundKey) {
+			found = true
+			foundKey = key
+		}
+		if !lowestFound || key < lowestKey {
+			lowestFound = true
+			lowestKey = key
+		}
+	}
+	if found {
+		return s[foundKey]
+	}
+	if lowestFound {
+		return s[lowestKey]
+	}
+	return slog.LevelInfo
+}
+
+// SubscribeSlogLevelVar keeps levelVar in sync with the glog verbosity changed by the
+// LogLevelManager (e.g. returned by NewLogLevelSetter), including auto-resets.
+// levelVar is initialized with the current log level of logLevelManager.
+//
+// Example:
+//
+//	levelVar := new(slog.LevelVar)
+//	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: levelVar})))
+//
+//	logLevelSetter := log.NewLogLevelSetter(glog.Level(1), 5*time.Minute)
+//	unsubscribe := log.SubscribeSlogLevelVar(logLevelSetter, levelVar, log.DefaultSlogLevelMapping())
+//	defer unsubscribe()
+//
+// Returns a function that stops the synchronization.
+func SubscribeSlogLevelVar(
+	logLevelManager LogLevelManager,
+	levelVar *slog.LevelVar,
+	mapping SlogLevelMapping,
+) (unsubscribe func()) {
+	levelVar.Set(mapping.SlogLevel(logLevelManager.State().LogLevel))
+	return logLevelManager.Subscribe(func(oldLevel glog.Level, newLevel glog.Level) {
+		levelVar.Set(mapping.SlogLevel(newLevel))
+	})
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package log_test
+
+import (
+	"log/slog"
+
+	"github.com/golang/glog"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/log"
+	"github.com/bborbe/log/mocks"
+)
+
+var _ = Describe("Log SlogLevelBridge", func() {
+	Context("SlogLevelMapping", func() {
+		DescribeTable("DefaultSlogLevelMapping",
+			func(logLevel glog.Level, expected slog.Level) {
+				Expect(log.DefaultSlogLevelMapping().SlogLevel(logLevel)).To(Equal(expected))
+			},
+			Entry("v=-1", glog.Level(-1), slog.LevelInfo),
+			Entry("v=0", glog.Level(0), slog.LevelInfo),
+			Entry("v=1", glog.Level(1), slog.LevelInfo),
+			Entry("v=2", glog.Level(2), slog.LevelDebug),
+			Entry("v=3", glog.Level(3), slog.LevelDebug),
+			Entry("v=4", glog.Level(4), slog.LevelDebug-4),
+			Entry("v=10", glog.Level(10), slog.LevelDebug-4),
+		)
+		It("returns a new default mapping on every call", func() {
+			mapping := log.DefaultSlogLevelMapping()
+			mapping[0] = slog.LevelError
+			Expect(log.DefaultSlogLevelMapping().SlogLevel(0)).To(Equal(slog.LevelInfo))
+		})
+		It("returns info for empty mapping", func() {
+			Expect(log.SlogLevelMapping{}.SlogLevel(3)).To(Equal(slog.LevelInfo))
+		})
+		It("uses custom mapping", func() {
+			mapping := log.SlogLevelMapping{
+				1: slog.LevelWarn,
+				5: slog.LevelDebug,
+			}
+			Expect(mapping.SlogLevel(0)).To(Equal(slog.LevelWarn))
+			Expect(mapping.SlogLevel(4)).To(Equal(slog.LevelWarn))
+			Expect(mapping.SlogLevel(5)).To(Equal(slog.LevelDebug))
+		})
+	})
+
+	Context("SubscribeSlogLevelVar", func() {
+		var logLevelManager *mocks.LogLevelManager
+		var levelVar *slog.LevelVar
+		var unsubscribeCalled bool
+
+		BeforeEach(func() {
+			unsubscribeCalled = false
+			logLevelManager = &mocks.LogLevelManager{}
+			logLevelManager.StateReturns(log.LogLevelState{LogLevel: 3})
+			logLevelManager.SubscribeReturns(func() { unsubscribeCalled = true })
+			levelVar = new(slog.LevelVar)
+		})
+
+		It("initializes level var with current glog level", func() {
+			log.SubscribeSlogLevelVar(logLevelManager, levelVar, log.DefaultSlogLevelMapping())
+			Expect(levelVar.Level()).To(Equal(slog.LevelDebug))
+		})
+
+		It("updates level var on change", func() {
+			log.SubscribeSlogLevelVar(logLevelManager, levelVar, log.DefaultSlogLevelMapping())
+			Expect(logLevelManager.SubscribeCallCount()).To(Equal(1))
+			fn := logLevelManager.SubscribeArgsForCall(0)
+			fn(3, 5)
+			Expect(levelVar.Level()).To(Equal(slog.LevelDebug - 4))
+			fn(5, 0)
+			Expect(levelVar.Level()).To(Equal(slog.LevelInfo))
+		})
+
+		It("returns unsubscribe", func() {
+			unsubscribe := log.SubscribeSlogLevelVar(
+				logLevelManager,
+				levelVar,
+				log.DefaultSlogLevelMapping(),
+			)
+			unsubscribe()
+			Expect(unsubscribeCalled).To(BeTrue())
+		})
+	})
+})
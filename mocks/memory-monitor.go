@@ -2,12 +2,44 @@
 package mocks
 
 import (
+	"context"
 	"sync"
 
 	"github.com/bborbe/log"
 )
 
 type MemoryMonitor struct {
+	BeginStub        func(string) log.MemoryPhase
+	beginMutex       sync.RWMutex
+	beginArgsForCall []struct {
+		arg1 string
+	}
+	beginReturns struct {
+		result1 log.MemoryPhase
+	}
+	beginReturnsOnCall map[int]struct {
+		result1 log.MemoryPhase
+	}
+	CheckpointsStub        func() []log.MemoryCheckpoint
+	checkpointsMutex       sync.RWMutex
+	checkpointsArgsForCall []struct {
+	}
+	checkpointsReturns struct {
+		result1 []log.MemoryCheckpoint
+	}
+	checkpointsReturnsOnCall map[int]struct {
+		result1 []log.MemoryCheckpoint
+	}
+	EndReportStub        func() log.MemoryEndReport
+	endReportMutex       sync.RWMutex
+	endReportArgsForCall []struct {
+	}
+	endReportReturns struct {
+		result1 log.MemoryEndReport
+	}
+	endReportReturnsOnCall map[int]struct {
+		result1 log.MemoryEndReport
+	}
 	LogMemoryUsageStub        func(string)
 	logMemoryUsageMutex       sync.RWMutex
 	logMemoryUsageArgsForCall []struct {
@@ -27,10 +59,198 @@ type MemoryMonitor struct {
 		arg1 string
 		arg2 []interface{}
 	}
+	PhasesStub        func() log.MemoryPhaseSummary
+	phasesMutex       sync.RWMutex
+	phasesArgsForCall []struct {
+	}
+	phasesReturns struct {
+		result1 log.MemoryPhaseSummary
+	}
+	phasesReturnsOnCall map[int]struct {
+		result1 log.MemoryPhaseSummary
+	}
+	RunStub        func(context.Context) error
+	runMutex       sync.RWMutex
+	runArgsForCall []struct {
+		arg1 context.Context
+	}
+	runReturns struct {
+		result1 error
+	}
+	runReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *MemoryMonitor) Begin(arg1 string) log.MemoryPhase {
+	fake.beginMutex.Lock()
+	ret, specificReturn := fake.beginReturnsOnCall[len(fake.beginArgsForCall)]
+	fake.beginArgsForCall = append(fake.beginArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.BeginStub
+	fakeReturns := fake.beginReturns
+	fake.recordInvocation("Begin", []interface{}{arg1})
+	fake.beginMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *MemoryMonitor) BeginCallCount() int {
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	return len(fake.beginArgsForCall)
+}
+
+func (fake *MemoryMonitor) BeginCalls(stub func(string) log.MemoryPhase) {
+	fake.beginMutex.Lock()
+	defer fake.beginMutex.Unlock()
+	fake.BeginStub = stub
+}
+
+func (fake *MemoryMonitor) BeginArgsForCall(i int) string {
+	fake.beginMutex.RLock()
+	defer fake.beginMutex.RUnlock()
+	argsForCall := fake.beginArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *MemoryMonitor) BeginReturns(result1 log.MemoryPhase) {
+	fake.beginMutex.Lock()
+	defer fake.beginMutex.Unlock()
+	fake.BeginStub = nil
+	fake.beginReturns = struct {
+		result1 log.MemoryPhase
+	}{result1}
+}
+
+func (fake *MemoryMonitor) BeginReturnsOnCall(i int, result1 log.MemoryPhase) {
+	fake.beginMutex.Lock()
+	defer fake.beginMutex.Unlock()
+	fake.BeginStub = nil
+	if fake.beginReturnsOnCall == nil {
+		fake.beginReturnsOnCall = make(map[int]struct {
+			result1 log.MemoryPhase
+		})
+	}
+	fake.beginReturnsOnCall[i] = struct {
+		result1 log.MemoryPhase
+	}{result1}
+}
+
+func (fake *MemoryMonitor) Checkpoints() []log.MemoryCheckpoint {
+	fake.checkpointsMutex.Lock()
+	ret, specificReturn := fake.checkpointsReturnsOnCall[len(fake.checkpointsArgsForCall)]
+	fake.checkpointsArgsForCall = append(fake.checkpointsArgsForCall, struct {
+	}{})
+	stub := fake.CheckpointsStub
+	fakeReturns := fake.checkpointsReturns
+	fake.recordInvocation("Checkpoints", []interface{}{})
+	fake.checkpointsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *MemoryMonitor) CheckpointsCallCount() int {
+	fake.checkpointsMutex.RLock()
+	defer fake.checkpointsMutex.RUnlock()
+	return len(fake.checkpointsArgsForCall)
+}
+
+func (fake *MemoryMonitor) CheckpointsCalls(stub func() []log.MemoryCheckpoint) {
+	fake.checkpointsMutex.Lock()
+	defer fake.checkpointsMutex.Unlock()
+	fake.CheckpointsStub = stub
+}
+
+func (fake *MemoryMonitor) CheckpointsReturns(result1 []log.MemoryCheckpoint) {
+	fake.checkpointsMutex.Lock()
+	defer fake.checkpointsMutex.Unlock()
+	fake.CheckpointsStub = nil
+	fake.checkpointsReturns = struct {
+		result1 []log.MemoryCheckpoint
+	}{result1}
+}
+
+func (fake *MemoryMonitor) CheckpointsReturnsOnCall(i int, result1 []log.MemoryCheckpoint) {
+	fake.checkpointsMutex.Lock()
+	defer fake.checkpointsMutex.Unlock()
+	fake.CheckpointsStub = nil
+	if fake.checkpointsReturnsOnCall == nil {
+		fake.checkpointsReturnsOnCall = make(map[int]struct {
+			result1 []log.MemoryCheckpoint
+		})
+	}
+	fake.checkpointsReturnsOnCall[i] = struct {
+		result1 []log.MemoryCheckpoint
+	}{result1}
+}
+
+func (fake *MemoryMonitor) EndReport() log.MemoryEndReport {
+	fake.endReportMutex.Lock()
+	ret, specificReturn := fake.endReportReturnsOnCall[len(fake.endReportArgsForCall)]
+	fake.endReportArgsForCall = append(fake.endReportArgsForCall, struct {
+	}{})
+	stub := fake.EndReportStub
+	fakeReturns := fake.endReportReturns
+	fake.recordInvocation("EndReport", []interface{}{})
+	fake.endReportMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *MemoryMonitor) EndReportCallCount() int {
+	fake.endReportMutex.RLock()
+	defer fake.endReportMutex.RUnlock()
+	return len(fake.endReportArgsForCall)
+}
+
+func (fake *MemoryMonitor) EndReportCalls(stub func() log.MemoryEndReport) {
+	fake.endReportMutex.Lock()
+	defer fake.endReportMutex.Unlock()
+	fake.EndReportStub = stub
+}
+
+func (fake *MemoryMonitor) EndReportReturns(result1 log.MemoryEndReport) {
+	fake.endReportMutex.Lock()
+	defer fake.endReportMutex.Unlock()
+	fake.EndReportStub = nil
+	fake.endReportReturns = struct {
+		result1 log.MemoryEndReport
+	}{result1}
+}
+
+func (fake *MemoryMonitor) EndReportReturnsOnCall(i int, result1 log.MemoryEndReport) {
+	fake.endReportMutex.Lock()
+	defer fake.endReportMutex.Unlock()
+	fake.EndReportStub = nil
+	if fake.endReportReturnsOnCall == nil {
+		fake.endReportReturnsOnCall = make(map[int]struct {
+			result1 log.MemoryEndReport
+		})
+	}
+	fake.endReportReturnsOnCall[i] = struct {
+		result1 log.MemoryEndReport
+	}{result1}
+}
+
 func (fake *MemoryMonitor) LogMemoryUsage(arg1 string) {
 	fake.logMemoryUsageMutex.Lock()
 	fake.logMemoryUsageArgsForCall = append(fake.logMemoryUsageArgsForCall, struct {
@@ -144,6 +364,120 @@ func (fake *MemoryMonitor) LogMemoryUsagefArgsForCall(i int) (string, []interfac
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *MemoryMonitor) Phases() log.MemoryPhaseSummary {
+	fake.phasesMutex.Lock()
+	ret, specificReturn := fake.phasesReturnsOnCall[len(fake.phasesArgsForCall)]
+	fake.phasesArgsForCall = append(fake.phasesArgsForCall, struct {
+	}{})
+	stub := fake.PhasesStub
+	fakeReturns := fake.phasesReturns
+	fake.recordInvocation("Phases", []interface{}{})
+	fake.phasesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *MemoryMonitor) PhasesCallCount() int {
+	fake.phasesMutex.RLock()
+	defer fake.phasesMutex.RUnlock()
+	return len(fake.phasesArgsForCall)
+}
+
+func (fake *MemoryMonitor) PhasesCalls(stub func() log.MemoryPhaseSummary) {
+	fake.phasesMutex.Lock()
+	defer fake.phasesMutex.Unlock()
+	fake.PhasesStub = stub
+}
+
+func (fake *MemoryMonitor) PhasesReturns(result1 log.MemoryPhaseSummary) {
+	fake.phasesMutex.Lock()
+	defer fake.phasesMutex.Unlock()
+	fake.PhasesStub = nil
+	fake.phasesReturns = struct {
+		result1 log.MemoryPhaseSummary
+	}{result1}
+}
+
+func (fake *MemoryMonitor) PhasesReturnsOnCall(i int, result1 log.MemoryPhaseSummary) {
+	fake.phasesMutex.Lock()
+	defer fake.phasesMutex.Unlock()
+	fake.PhasesStub = nil
+	if fake.phasesReturnsOnCall == nil {
+		fake.phasesReturnsOnCall = make(map[int]struct {
+			result1 log.MemoryPhaseSummary
+		})
+	}
+	fake.phasesReturnsOnCall[i] = struct {
+		result1 log.MemoryPhaseSummary
+	}{result1}
+}
+
+func (fake *MemoryMonitor) Run(arg1 context.Context) error {
+	fake.runMutex.Lock()
+	ret, specificReturn := fake.runReturnsOnCall[len(fake.runArgsForCall)]
+	fake.runArgsForCall = append(fake.runArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.RunStub
+	fakeReturns := fake.runReturns
+	fake.recordInvocation("Run", []interface{}{arg1})
+	fake.runMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *MemoryMonitor) RunCallCount() int {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return len(fake.runArgsForCall)
+}
+
+func (fake *MemoryMonitor) RunCalls(stub func(context.Context) error) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = stub
+}
+
+func (fake *MemoryMonitor) RunArgsForCall(i int) context.Context {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	argsForCall := fake.runArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *MemoryMonitor) RunReturns(result1 error) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = nil
+	fake.runReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *MemoryMonitor) RunReturnsOnCall(i int, result1 error) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = nil
+	if fake.runReturnsOnCall == nil {
+		fake.runReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.runReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *MemoryMonitor) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()